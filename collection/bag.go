@@ -0,0 +1,301 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// The datatypes/collection package provides new structures and
+// behaviours to the iteration of non-sorted unique element and homogeneous
+// lists accepting primitives types and complex user structs as well.
+
+// This part of package contains the bag (multiset) behaviour
+
+package collection
+
+import (
+	"iter"
+	"reflect"
+	"sort"
+
+	"github.com/jaimelopez/datatypes/generic"
+)
+
+// Bag represents a homogeneous multiset, a list which counts how many
+// times every element has been added instead of rejecting the duplicated ones
+// The distinct elements keep the order in which they were added for the first time.
+// The zero value is an empty bag ready to use
+type Bag struct {
+	definition reflect.Type
+	distinct   []Element
+	counts     *index
+	size       int
+}
+
+// ElementCount represents an element of a bag and its number of occurrences
+type ElementCount struct {
+	Element Element
+	Count   int
+}
+
+// Add a single occurrence of the element to the bag
+// The bag must to be homogeneous so the specified element should be the same
+// type such the other elements already stored in the bag
+func (col *Bag) Add(element Element) error {
+	return col.AddN(element, 1)
+}
+
+// AddN adds the specified number of occurrences of the element to the bag
+// The number of occurrences must be greater than zero, otherwise it returns ErrInvalidCount
+func (col *Bag) AddN(element Element, n int) error {
+	if n <= 0 {
+		return ErrInvalidCount
+	}
+
+	if col.IsEmpty() {
+		col.definition = reflect.TypeOf(element)
+		col.counts = newIndex(col.definition)
+	} else if !col.isHomogeneousWith(element) {
+		return ErrInvalidElementType
+	}
+
+	if !col.counts.contains(element) {
+		col.distinct = append(col.distinct, element)
+	}
+
+	col.counts.addN(element, n)
+	col.size += n
+
+	return nil
+}
+
+// AddRange adds an occurrence of every element of the range (slice) to the bag
+// If the parameter can't be converted to a iterable data type it's return an error.
+// The range is added all or nothing, so if any element has a different type
+// the bag is left untouched and a TypeMismatchError is returned,
+// or all of them joined if the JoinErrors option is specified
+func (col *Bag) AddRange(elements ElementList, options ...RangeOption) error {
+	slice, err := generic.ToSlice(elements)
+
+	if err != nil {
+		return err
+	}
+
+	if len(slice) == 0 {
+		return nil
+	}
+
+	definition := col.definition
+
+	if col.IsEmpty() {
+		definition = reflect.TypeOf(slice[0])
+	}
+
+	err = checkElements(slice, definition, func(int, Element) error {
+		return nil
+	}, options)
+
+	if err != nil {
+		return err
+	}
+
+	for _, element := range slice {
+		if err = col.Add(element); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Remove a single occurrence of the element from the bag
+// If it's not found the method will return an error
+func (col *Bag) Remove(element Element) error {
+	return col.remove(element, 1)
+}
+
+// RemoveAll removes all the occurrences of the element from the bag
+// If it's not found the method will return an error
+func (col *Bag) RemoveAll(element Element) error {
+	return col.remove(element, col.Count(element))
+}
+
+// Count returns the number of occurrences of the element in the bag
+func (col *Bag) Count(element Element) int {
+	if col.IsEmpty() || !col.isHomogeneousWith(element) {
+		return 0
+	}
+
+	return col.counts.count(element)
+}
+
+// Contains checks if the bag has at least one occurrence of the element
+func (col *Bag) Contains(element Element) bool {
+	return col.Count(element) > 0
+}
+
+// Distinct returns a new collection with the distinct elements of the bag
+// in the order they were added for the first time
+func (col *Bag) Distinct() *Collection {
+	return newDerivedCollection(append([]Element(nil), col.distinct...))
+}
+
+// Elements returns all the occurrences stored in the bag
+// The occurrences of the same element are returned together
+func (col *Bag) Elements() []Element {
+	elements := make([]Element, 0, col.size)
+
+	for _, element := range col.distinct {
+		for occurrence := col.counts.count(element); occurrence > 0; occurrence-- {
+			elements = append(elements, element)
+		}
+	}
+
+	return elements
+}
+
+// Iter returns an iterator over the distinct elements of the bag and their number of occurrences
+func (col *Bag) Iter() iter.Seq2[Element, int] {
+	return func(yield func(Element, int) bool) {
+		for _, element := range col.distinct {
+			if !yield(element, col.counts.count(element)) {
+				return
+			}
+		}
+	}
+}
+
+// MostCommon returns the k elements with more occurrences sorted from the most common
+// Elements with the same number of occurrences keep the order they were added.
+// If k is negative or greater than the number of distinct elements, all of them are returned
+func (col *Bag) MostCommon(k int) []ElementCount {
+	counts := make([]ElementCount, 0, len(col.distinct))
+
+	for element, count := range col.Iter() {
+		counts = append(counts, ElementCount{element, count})
+	}
+
+	sort.SliceStable(counts, func(first int, second int) bool {
+		return counts[first].Count > counts[second].Count
+	})
+
+	if k >= 0 && k < len(counts) {
+		counts = counts[:k]
+	}
+
+	return counts
+}
+
+// Union returns a new bag with the elements of both bags
+// keeping for every element the greatest number of occurrences
+func (col *Bag) Union(bag *Bag) (*Bag, error) {
+	return col.combine(bag, func(first int, second int) int {
+		return max(first, second)
+	})
+}
+
+// Intersection returns a new bag with the elements contained in both bags
+// keeping for every element the lowest number of occurrences
+func (col *Bag) Intersection(bag *Bag) (*Bag, error) {
+	return col.combine(bag, func(first int, second int) int {
+		return min(first, second)
+	})
+}
+
+// Sum returns a new bag with the elements of both bags
+// adding the occurrences of every element
+func (col *Bag) Sum(bag *Bag) (*Bag, error) {
+	return col.combine(bag, func(first int, second int) int {
+		return first + second
+	})
+}
+
+// Difference returns a new bag with the occurrences of the instanced bag
+// which are not in the parameter bag
+func (col *Bag) Difference(bag *Bag) (*Bag, error) {
+	return col.combine(bag, func(first int, second int) int {
+		return first - second
+	})
+}
+
+// Size returns the number of occurrences inside the bag
+func (col *Bag) Size() int {
+	return col.size
+}
+
+// IsEmpty checks if the bag is empty or not
+func (col *Bag) IsEmpty() bool {
+	return col.Size() == 0
+}
+
+func (col *Bag) isHomogeneousWith(element Element) bool {
+	return col.definition == reflect.TypeOf(element)
+}
+
+// remove subtracts n occurrences of the element, dropping it when there are no more
+func (col *Bag) remove(element Element, n int) error {
+	if !col.isHomogeneousWith(element) {
+		return ErrInvalidElementType
+	}
+
+	if !col.Contains(element) {
+		return ErrElementNotFound
+	}
+
+	col.counts.removeN(element, n)
+	col.size -= n
+
+	if col.counts.contains(element) {
+		return nil
+	}
+
+	for position, current := range col.distinct {
+		if col.counts.equal(current, element) {
+			col.distinct = append(col.distinct[:position:position], col.distinct[position+1:]...)
+
+			break
+		}
+	}
+
+	return nil
+}
+
+// combine returns a new bag whose occurrences of every element are
+// computed by f from the occurrences in both bags
+// The elements whose resulting number of occurrences isn't positive are discarded
+func (col *Bag) combine(bag *Bag, f func(int, int) int) (*Bag, error) {
+	if !col.IsEmpty() && !bag.IsEmpty() && col.definition != bag.definition {
+		return nil, ErrInvalidElementType
+	}
+
+	result := NewEmptyBag()
+
+	for element, occurrences := range col.Iter() {
+		if count := f(occurrences, bag.Count(element)); count > 0 {
+			result.AddN(element, count)
+		}
+	}
+
+	for element, occurrences := range bag.Iter() {
+		if col.Contains(element) {
+			continue
+		}
+
+		if count := f(0, occurrences); count > 0 {
+			result.AddN(element, count)
+		}
+	}
+
+	return result, nil
+}
+
+// NewEmptyBag instances a new empty bag
+func NewEmptyBag() *Bag {
+	return new(Bag)
+}
+
+// NewBag allows to instance a new Bag with a group of elements
+// The elements can be repeated but they must be homogeneous
+func NewBag(elements ElementList) (*Bag, error) {
+	bag := NewEmptyBag()
+	err := bag.AddRange(elements)
+
+	return bag, err
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// The datatypes/collection package provides new structures and
+// behaviours to the iteration of non-sorted unique element and homogeneous
+// lists accepting primitives types and complex user structs as well.
+
+// This part of package contains the tests for the bag (multiset) behaviour
+
+package collection
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBagAddMethods(test *testing.T) {
+	bag := NewEmptyBag()
+
+	assert.Nil(test, bag.Add("a"), "Unexpected error adding an element to the bag")
+	assert.Nil(test, bag.Add("a"), "Duplicated elements should be counted by the bag")
+	assert.Nil(test, bag.AddN("b", 3), "Unexpected error adding several occurrences to the bag")
+	assert.Equal(test, ErrInvalidCount, bag.AddN("b", 0), "Non-positive counts should return an error")
+	assert.Equal(test, ErrInvalidElementType, bag.Add(1), "Bags must to be homogeneous")
+
+	assert.Equal(test, 2, bag.Count("a"), "Wrong count of a repeated element")
+	assert.Equal(test, 3, bag.Count("b"), "Wrong count of an element added several times")
+	assert.Equal(test, 0, bag.Count("c"), "Not stored elements shouldn't be counted")
+	assert.Equal(test, 5, bag.Size(), "Size should count all the occurrences")
+	assert.Equal(test, []Element{"a", "a", "b", "b", "b"}, bag.Elements(), "Wrong occurrences returned by Elements method")
+}
+
+func TestBagAddRangeMethod(test *testing.T) {
+	bag, err := NewBag([]int{1, 2, 1})
+
+	assert.Nil(test, err, "Unexpected error instancing a bag")
+	assert.Equal(test, 2, bag.Count(1), "Repeated elements of a range should be counted")
+
+	err = bag.AddRange([]Element{3, "4"})
+
+	assert.ErrorIs(test, err, ErrInvalidElementType, "Heterogeneous ranges should return an error")
+	assert.Equal(test, 3, bag.Size(), "Failed AddRange calls should leave the bag untouched")
+
+	err = bag.AddRange([]Element{"3", 4, "5"}, JoinErrors())
+
+	assert.Equal(test, errors.Join(
+		&TypeMismatchError{Expected: reflect.TypeOf(0), Actual: reflect.TypeOf(""), Index: 0},
+		&TypeMismatchError{Expected: reflect.TypeOf(0), Actual: reflect.TypeOf(""), Index: 2},
+	), err, "JoinErrors option should return all the failures of bag AddRange")
+	assert.Equal(test, 3, bag.Size(), "Failed AddRange calls should leave the bag untouched")
+}
+
+func TestBagZeroValue(test *testing.T) {
+	var bag Bag
+
+	assert.Equal(test, 0, bag.Count("a"), "Zero value bags shouldn't count any element")
+	assert.Error(test, bag.Remove("a"), "Removing from a zero value bag should return an error")
+	assert.Nil(test, bag.AddN("a", 2), "Unexpected error adding to a zero value bag")
+	assert.Equal(test, 2, bag.Count("a"), "Wrong count of an element added to a zero value bag")
+}
+
+func TestBagRemoveMethods(test *testing.T) {
+	bag, _ := NewBag([]string{"a", "a", "b"})
+
+	assert.Nil(test, bag.Remove("a"), "Unexpected error removing an occurrence")
+	assert.Equal(test, 1, bag.Count("a"), "Remove method should subtract a single occurrence")
+	assert.Nil(test, bag.Remove("a"), "Unexpected error removing the last occurrence")
+	assert.False(test, bag.Contains("a"), "Elements without occurrences shouldn't be contained")
+	assert.Equal(test, ErrElementNotFound, bag.Remove("a"), "Removing a not stored element should return an error")
+	assert.Equal(test, ErrInvalidElementType, bag.Remove(1), "Removing elements of other types should return an error")
+
+	bag.AddN("c", 4)
+
+	assert.Nil(test, bag.RemoveAll("c"), "Unexpected error removing all the occurrences")
+	assert.Equal(test, []Element{"b"}, bag.Elements(), "RemoveAll method should remove all the occurrences")
+	assert.Equal(test, ErrElementNotFound, bag.RemoveAll("c"), "Removing all of a not stored element should return an error")
+}
+
+func TestBagWithNonComparableElements(test *testing.T) {
+	bag, _ := NewBag([][]int{{1}, {2}, {1}})
+
+	assert.Equal(test, 2, bag.Count([]int{1}), "Non-comparable elements should be counted")
+	assert.Nil(test, bag.RemoveAll([]int{1}), "Unexpected error removing non-comparable elements")
+	assert.Equal(test, []Element{[]int{2}}, bag.Elements(), "Wrong elements after removing non-comparable elements")
+}
+
+func TestBagDistinctMethod(test *testing.T) {
+	bag, _ := NewBag([]string{"b", "a", "b"})
+	distinct := bag.Distinct()
+
+	assert.Equal(test, []Element{"b", "a"}, distinct.Elements(), "Distinct method should return each element once")
+	assert.True(test, distinct.Contains("a"), "Distinct collection should be usable as any other collection")
+
+	counts := map[Element]int{}
+
+	for element, count := range bag.Iter() {
+		counts[element] = count
+	}
+
+	assert.Equal(test, map[Element]int{"a": 1, "b": 2}, counts, "Wrong counts returned by Iter method")
+}
+
+func TestBagMostCommonMethod(test *testing.T) {
+	bag, _ := NewBag([]string{"a", "b", "c", "b", "c", "c", "d", "b"})
+
+	assert.Equal(test, []ElementCount{{"b", 3}, {"c", 3}}, bag.MostCommon(2), "Wrong most common elements")
+	assert.Equal(test, []ElementCount{{"b", 3}, {"c", 3}, {"a", 1}, {"d", 1}}, bag.MostCommon(-1), "Negative k should return all the elements")
+	assert.Empty(test, bag.MostCommon(0), "Zero k shouldn't return elements")
+}
+
+func TestBagAlgebra(test *testing.T) {
+	first, _ := NewBag([]string{"a", "a", "a", "b", "c"})
+	second, _ := NewBag([]string{"a", "b", "b", "d"})
+
+	counts := func(bag *Bag) map[Element]int {
+		result := map[Element]int{}
+
+		for element, count := range bag.Iter() {
+			result[element] = count
+		}
+
+		return result
+	}
+
+	union, _ := first.Union(second)
+	intersection, _ := first.Intersection(second)
+	sum, _ := first.Sum(second)
+	difference, _ := first.Difference(second)
+
+	assert.Equal(test, map[Element]int{"a": 3, "b": 2, "c": 1, "d": 1}, counts(union), "Union should keep the greatest counts")
+	assert.Equal(test, map[Element]int{"a": 1, "b": 1}, counts(intersection), "Intersection should keep the lowest counts")
+	assert.Equal(test, map[Element]int{"a": 4, "b": 3, "c": 1, "d": 1}, counts(sum), "Sum should add the counts")
+	assert.Equal(test, map[Element]int{"a": 2, "c": 1}, counts(difference), "Difference should subtract the counts")
+	assert.Equal(test, 9, sum.Size(), "Wrong size of the sum")
+
+	numbers, _ := NewBag([]int{1})
+	_, err := first.Union(numbers)
+
+	assert.Equal(test, ErrInvalidElementType, err, "Bags of different types can't be combined")
+
+	empty, _ := NewEmptyBag().Sum(numbers)
+
+	assert.Equal(test, 1, empty.Count(1), "Empty bags should be combinable with any other bag")
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// The datatypes/collection package provides new structures and
+// behaviours to the iteration of non-sorted unique element and homogeneous
+// lists accepting primitives types and complex user structs as well.
+
+// This part of package contains the binary encoding and decoding of collections
+
+package collection
+
+import (
+	"bytes"
+	"encoding/gob"
+
+	"github.com/jaimelopez/datatypes/internal/codec"
+)
+
+// GobEncode encodes the elements of the collection with encoding/gob
+// Any element type supported by gob can be encoded, although user types
+// must be registered with gob.Register as they're stored as interface values
+func (col *Collection) GobEncode() ([]byte, error) {
+	var buffer bytes.Buffer
+
+	if err := gob.NewEncoder(&buffer).Encode(append([]Element{}, col.elements...)); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// GobDecode decodes the elements encoded by GobEncode replacing the elements of the collection
+// The decoded elements must be unique and homogeneous, otherwise it returns a
+// *DecodeError with the position of the offending element and the collection is left untouched
+func (col *Collection) GobDecode(data []byte) error {
+	var elements []Element
+
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&elements); err != nil {
+		return err
+	}
+
+	return col.replace(elements)
+}
+
+// MarshalBinary encodes the collection in a compact and versioned binary format
+// which stores the type of the elements and a checksum of the data
+// Only booleans, numbers and strings are supported, otherwise it returns ErrUnsupportedType
+func (col *Collection) MarshalBinary() ([]byte, error) {
+	encoder, err := codec.NewEncoder(col.definition)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, element := range col.elements {
+		if err := encoder.Encode(element); err != nil {
+			return nil, err
+		}
+	}
+
+	return encoder.Bytes(), nil
+}
+
+// UnmarshalBinary decodes the data encoded by MarshalBinary replacing the elements of the collection
+// Named types must be registered with DecodeAs to be decoded. If the data is corrupted
+// it returns ErrCorruptedData and the collection is left untouched
+func (col *Collection) UnmarshalBinary(data []byte) error {
+	decoder, err := codec.NewDecoder(data, col.decoding)
+
+	if err != nil {
+		return err
+	}
+
+	var elements []Element
+
+	for decoder.More() {
+		element, err := decoder.Decode()
+
+		if err != nil {
+			return err
+		}
+
+		elements = append(elements, element)
+	}
+
+	return col.replace(elements)
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// The datatypes/collection package provides new structures and
+// behaviours to the iteration of non-sorted unique element and homogeneous
+// lists accepting primitives types and complex user structs as well.
+
+// This part of package contains the tests for the binary encoding and decoding
+
+package collection
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"testing"
+
+	"github.com/jaimelopez/datatypes/internal/codec"
+	"github.com/stretchr/testify/assert"
+)
+
+type binaryTestStruct struct {
+	Name string
+}
+
+type binaryTestCelsius float64
+
+func init() {
+	gob.Register(binaryTestStruct{})
+}
+
+func TestGobEncoding(test *testing.T) {
+	collection := NewCollection([]binaryTestStruct{{"Jaime"}, {"Ana"}})
+
+	var buffer bytes.Buffer
+
+	assert.Nil(test, gob.NewEncoder(&buffer).Encode(collection), "Unexpected error on gob encoding")
+
+	decoded := NewEmptyCollection()
+
+	assert.Nil(test, gob.NewDecoder(&buffer).Decode(decoded), "Unexpected error on gob decoding")
+	assert.Equal(test, collection.Elements(), decoded.Elements(), "Wrong round trip on gob encoding")
+	assert.Equal(test, collection.definition, decoded.definition, "Definition should be preserved on gob encoding")
+
+	buffer.Reset()
+	gob.NewEncoder(&buffer).Encode([]Element{1, "1"})
+
+	err := decoded.GobDecode(buffer.Bytes())
+
+	assert.True(test, errors.Is(err, ErrInvalidElementType), "Heterogeneous elements should return an error on gob decoding")
+	assert.Equal(test, collection.Elements(), decoded.Elements(), "Failed decodings should leave the collection untouched")
+}
+
+func TestBinaryMarshaling(test *testing.T) {
+	collection := NewCollection([]int{3, -1, 1 << 40})
+
+	data, err := collection.MarshalBinary()
+
+	assert.Nil(test, err, "Unexpected error on binary marshaling")
+
+	decoded := NewEmptyCollection()
+
+	assert.Nil(test, decoded.UnmarshalBinary(data), "Unexpected error on binary unmarshaling")
+	assert.Equal(test, collection.Elements(), decoded.Elements(), "Wrong round trip on binary marshaling")
+
+	empty, _ := NewEmptyCollection().MarshalBinary()
+
+	assert.Nil(test, decoded.UnmarshalBinary(empty), "Unexpected error unmarshaling an empty collection")
+	assert.True(test, decoded.IsEmpty(), "Wrong round trip of an empty collection")
+
+	_, err = NewCollection([]binaryTestStruct{{"Jaime"}}).MarshalBinary()
+
+	assert.Equal(test, ErrUnsupportedType, err, "Non primitive elements should return an error on binary marshaling")
+}
+
+func TestBinaryMarshalingOfNamedTypes(test *testing.T) {
+	collection := NewCollection([]binaryTestCelsius{36.5, 40})
+
+	data, _ := collection.MarshalBinary()
+
+	assert.Equal(test, ErrUnsupportedType, NewEmptyCollection().UnmarshalBinary(data), "Named types should be registered to be unmarshaled")
+
+	decoded := NewEmptyCollection().DecodeAs(binaryTestCelsius(0))
+
+	assert.Nil(test, decoded.UnmarshalBinary(data), "Unexpected error unmarshaling a registered type")
+	assert.Equal(test, collection.Elements(), decoded.Elements(), "Wrong round trip of a registered type")
+}
+
+func TestBinaryUnmarshalingOfCorruptedData(test *testing.T) {
+	collection := NewCollection([]string{"a", "b"})
+	data, _ := collection.MarshalBinary()
+	data[len(data)/2] ^= 0xFF
+
+	assert.Equal(test, ErrCorruptedData, collection.UnmarshalBinary(data), "Corrupted data should return an error")
+	assert.Equal(test, []Element{"a", "b"}, collection.Elements(), "Failed decodings should leave the collection untouched")
+}
+
+func FuzzUnmarshalBinary(fuzz *testing.F) {
+	valid, _ := NewCollection([]string{"a", "b"}).MarshalBinary()
+	fuzz.Add(valid)
+
+	fuzz.Fuzz(func(test *testing.T, data []byte) {
+		collection := NewEmptyCollection()
+
+		// The checksum is recomputed so the fuzzed payload reaches the decoding
+		if collection.UnmarshalBinary(codec.Seal(data)) != nil {
+			return
+		}
+
+		// Any accepted data must be a valid collection which can be encoded again
+		encoded, err := collection.MarshalBinary()
+
+		assert.Nil(test, err, "Accepted data should be encoded again")
+		assert.Nil(test, NewEmptyCollection().UnmarshalBinary(encoded), "Encoded data should be accepted again")
+	})
+}
@@ -14,6 +14,7 @@ import (
 	"reflect"
 
 	"github.com/jaimelopez/datatypes/generic"
+	"github.com/jaimelopez/datatypes/internal/observer"
 )
 
 // Element represents a generic element
@@ -28,6 +29,9 @@ type ElementList interface{}
 type Collection struct {
 	definition reflect.Type
 	elements   []Element
+	index      *index
+	decoding   reflect.Type
+	observers  *observer.Hub[Event]
 }
 
 // Add a single element to the collection
@@ -38,6 +42,7 @@ type Collection struct {
 func (col *Collection) Add(element Element) error {
 	if col.IsEmpty() {
 		col.definition = reflect.TypeOf(element)
+		col.index = nil
 	} else if !col.isHomogeneousWith(element) {
 		return ErrInvalidElementType
 	}
@@ -46,35 +51,42 @@ func (col *Collection) Add(element Element) error {
 		return ErrDuplicatedElement
 	}
 
+	col.lookup().add(element)
 	col.elements = append(col.elements, element)
+	col.notify(Event{Type: AddEvent, Position: len(col.elements) - 1, New: element})
 
 	return nil
 }
 
 // AddRange inserts a range (slice) inside the collection
-// If the parameter can't be converted to a iterable data type it's return an error
-func (col *Collection) AddRange(elements ElementList) error {
+// If the parameter can't be converted to a iterable data type it's return an error.
+// The range is added all or nothing, so if any element can't be added
+// the collection is left untouched and a TypeMismatchError or a DuplicateError
+// is returned, or all of them joined if the JoinErrors option is specified
+func (col *Collection) AddRange(elements ElementList, options ...RangeOption) error {
 	slice, err := generic.ToSlice(elements)
 
 	if err != nil {
 		return err
 	}
 
-	for _, element := range slice {
-		err = col.Add(element)
+	if err = col.checkAdditions(slice, options); err != nil {
+		return err
+	}
 
-		if err != nil {
-			return err
-		}
+	defer col.batch()()
+
+	for _, element := range slice {
+		col.Add(element)
 	}
 
 	return nil
 }
 
 // AddCollection adds the elements contained in the parameter collection inside the instanced collection
-// If the parameter can't be converted to a iterable data type it's return an error
-func (col *Collection) AddCollection(collection *Collection) error {
-	return col.AddRange(collection.elements)
+// It follows the same rules than AddRange
+func (col *Collection) AddCollection(collection *Collection, options ...RangeOption) error {
+	return col.AddRange(collection.elements, options...)
 }
 
 // First returns the first element without removing it from the collection
@@ -82,11 +94,31 @@ func (col *Collection) First() Element {
 	return col.elements[0]
 }
 
+// TryFirst returns the first element without removing it from the collection
+// Unlike First, it returns ErrEmptyCollection if there are no elements instead of panicking
+func (col *Collection) TryFirst() (Element, error) {
+	if col.IsEmpty() {
+		return nil, ErrEmptyCollection
+	}
+
+	return col.First(), nil
+}
+
 // Last returns the last element without removing it from the collection
 func (col *Collection) Last() Element {
 	return col.elements[len(col.elements)-1]
 }
 
+// TryLast returns the last element without removing it from the collection
+// Unlike Last, it returns ErrEmptyCollection if there are no elements instead of panicking
+func (col *Collection) TryLast() (Element, error) {
+	if col.IsEmpty() {
+		return nil, ErrEmptyCollection
+	}
+
+	return col.Last(), nil
+}
+
 // ElementAt returns the element in the specified position
 // Although a collection is an unsorted data structure list and the position
 // of the elements could be changed, this method allows to return an specific index position.
@@ -95,6 +127,16 @@ func (col *Collection) ElementAt(position int) Element {
 	return col.elements[position]
 }
 
+// Get returns the element in the specified position
+// Unlike ElementAt, it returns ErrIndexOutOfRange if the position doesn't exist instead of panicking
+func (col *Collection) Get(position int) (Element, error) {
+	if !col.inRange(position) {
+		return nil, ErrIndexOutOfRange
+	}
+
+	return col.ElementAt(position), nil
+}
+
 // Elements returns the stored collection elements as slice of this elements
 // This is the proper way to iterate over all the elements of the collection
 // treating them as a normal range
@@ -106,22 +148,58 @@ func (col *Collection) Elements() []Element {
 // Keep in mind that this method will modify the collection elements subtracting that element
 func (col *Collection) Extract() Element {
 	element := col.First()
+	col.lookup().remove(element)
 	col.elements = col.elements[1:]
+	col.notify(Event{Type: ExtractEvent, Position: 0, Old: element})
 
 	return element
 }
 
+// TryExtract extracts the first element and return it
+// Unlike Extract, it returns ErrEmptyCollection if there are no elements instead of panicking.
+// Keep in mind that this method will modify the collection elements subtracting that element
+func (col *Collection) TryExtract() (Element, error) {
+	if col.IsEmpty() {
+		return nil, ErrEmptyCollection
+	}
+
+	return col.Extract(), nil
+}
+
 // Set a new value for a specified index element
+// The element can't be already stored in another position of the collection
+// since, as Add does, it would break the uniqueness of its elements
 func (col *Collection) Set(position int, element Element) error {
 	if !col.isHomogeneousWith(element) {
 		return ErrInvalidElementType
 	}
 
+	old := col.elements[position]
+	lookup := col.lookup()
+
+	if !lookup.equal(old, element) && lookup.contains(element) {
+		return ErrDuplicatedElement
+	}
+
+	lookup.remove(old)
+	lookup.add(element)
+
 	col.elements[position] = element
+	col.notify(Event{Type: SetEvent, Position: position, Old: old, New: element})
 
 	return nil
 }
 
+// SetAt sets a new value for a specified index element
+// Unlike Set, it returns ErrIndexOutOfRange if the position doesn't exist instead of panicking
+func (col *Collection) SetAt(position int, element Element) error {
+	if !col.inRange(position) {
+		return ErrIndexOutOfRange
+	}
+
+	return col.Set(position, element)
+}
+
 // Delete removes an specified already stored element
 // If it's not found the method will return an error
 func (col *Collection) Delete(element Element) error {
@@ -129,30 +207,45 @@ func (col *Collection) Delete(element Element) error {
 		return ErrInvalidElementType
 	}
 
-	for index, current := range col.elements {
-		if reflect.DeepEqual(current, element) {
-			col.elements = append(col.elements[:index], col.elements[index+1:]...)
+	if !col.Contains(element) {
+		return ErrElementNotFound
+	}
+
+	lookup := col.lookup()
+
+	for position, current := range col.elements {
+		if lookup.equal(current, element) {
+			col.elements = append(col.elements[:position], col.elements[position+1:]...)
+			lookup.remove(element)
+			col.notify(Event{Type: DeleteEvent, Position: position, Old: current})
 
-			return nil
+			break
 		}
 	}
 
-	return ErrElementNotFound
+	return nil
 }
 
 // DeleteRange removes all the found elements contained in the specified range (slice)
-// If the parameter can't be converted to a iterable data type it's return an error
-func (col *Collection) DeleteRange(elements ElementList) error {
+// If the parameter can't be converted to a iterable data type it's return an error.
+// The range is deleted all or nothing, so if any element can't be deleted
+// the collection is left untouched and a TypeMismatchError or a NotFoundError
+// is returned, or all of them joined if the JoinErrors option is specified
+func (col *Collection) DeleteRange(elements ElementList, options ...RangeOption) error {
 	slice, err := generic.ToSlice(elements)
 
 	if err != nil {
 		return err
 	}
 
+	if err = col.checkDeletions(slice, options); err != nil {
+		return err
+	}
+
+	defer col.batch()()
+
 	for _, element := range slice {
-		if err = col.Delete(element); err != nil {
-			return err
-		}
+		col.Delete(element)
 	}
 
 	return nil
@@ -160,20 +253,19 @@ func (col *Collection) DeleteRange(elements ElementList) error {
 
 // DeleteCollection removes all the found elements contained in the specified
 // collection from the instaced collection.
-// If the parameter can't be converted to a iterable data type it's return an error
-func (col *Collection) DeleteCollection(collection *Collection) error {
-	return col.DeleteRange(collection.elements)
+// It follows the same rules than DeleteRange
+func (col *Collection) DeleteCollection(collection *Collection, options ...RangeOption) error {
+	return col.DeleteRange(collection.elements, options...)
 }
 
 // Contains checks if the specified element is already existing in the collection
+// The lookup is done in constant time thanks to the hash index of the stored elements
 func (col *Collection) Contains(element Element) bool {
-	for _, iterator := range col.elements {
-		if reflect.DeepEqual(iterator, element) {
-			return true
-		}
+	if col.IsEmpty() || !col.isHomogeneousWith(element) {
+		return false
 	}
 
-	return false
+	return col.lookup().contains(element)
 }
 
 // ContainsAny checks if any of the parameter elements there are already contained in the collection
@@ -223,20 +315,121 @@ func (col *Collection) isHomogeneousWith(element Element) bool {
 	return col.definition == reflect.TypeOf(element)
 }
 
+// inRange checks if the specified position exists in the collection
+func (col *Collection) inRange(position int) bool {
+	return position >= 0 && position < len(col.elements)
+}
+
+// checkAdditions checks if all the elements could be added to the collection
+// returning the failures the Add calls would find
+func (col *Collection) checkAdditions(elements []interface{}, options []RangeOption) error {
+	if len(elements) == 0 {
+		return nil
+	}
+
+	definition := col.definition
+
+	if col.IsEmpty() {
+		definition = reflect.TypeOf(elements[0])
+	}
+
+	staged := newIndex(definition)
+
+	return checkElements(elements, definition, func(position int, element Element) error {
+		if col.Contains(element) || staged.contains(element) {
+			return &DuplicateError{Value: element, Index: position}
+		}
+
+		staged.add(element)
+
+		return nil
+	}, options)
+}
+
+// checkDeletions checks if all the elements could be deleted from the collection
+// returning the failures the Delete calls would find
+func (col *Collection) checkDeletions(elements []interface{}, options []RangeOption) error {
+	staged := newIndex(col.definition)
+
+	return checkElements(elements, col.definition, func(position int, element Element) error {
+		if !col.Contains(element) || staged.contains(element) {
+			return &NotFoundError{Value: element, Index: position}
+		}
+
+		staged.add(element)
+
+		return nil
+	}, options)
+}
+
+// lookup returns the index of the stored elements, building it if it doesn't exist yet
+func (col *Collection) lookup() *index {
+	if col.index == nil {
+		col.index = newIndex(col.definition)
+
+		for _, element := range col.elements {
+			col.index.add(element)
+		}
+	}
+
+	return col.index
+}
+
+// clone returns a copy of the collection which doesn't share its storage with the original
+func (col *Collection) clone() *Collection {
+	collection := &Collection{
+		definition: col.definition,
+		elements:   append([]Element(nil), col.elements...),
+		decoding:   col.decoding,
+	}
+
+	collection.lookup()
+
+	return collection
+}
+
+// replace stores the decoded elements in the collection if they're unique and homogeneous
+// The replacement is notified as a single ResetEvent
+func (col *Collection) replace(elements []Element) error {
+	decoded := NewEmptyCollection()
+	decoded.decoding = col.decoding
+
+	for position, element := range elements {
+		if err := decoded.Add(element); err != nil {
+			return &DecodeError{Index: position, Err: err}
+		}
+	}
+
+	decoded.observers = col.observers
+	*col = *decoded
+	col.notify(Event{Type: ResetEvent})
+
+	return nil
+}
+
 // NewEmptyCollection instances a new empty collection
 func NewEmptyCollection() *Collection {
 	return new(Collection)
 }
 
 // NewCollection allows to instance a new Collection with a group of elements
-// It accepts an enumerable
+// It accepts an enumerable, otherwise the parameter is added as a single element.
+// Unlike AddRange, the elements are added one by one, so if they're not unique
+// and homogeneous the collection keeps the ones before the first failing element
 func NewCollection(elements ElementList) *Collection {
 	collection := new(Collection)
-
-	err := collection.AddRange(elements)
+	slice, err := generic.ToSlice(elements)
 
 	if err != nil {
 		collection.Add(elements)
+
+		return collection
+	}
+
+	for _, element := range slice {
+		if collection.Add(element) != nil {
+			break
+		}
 	}
 
 	return collection
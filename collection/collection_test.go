@@ -281,4 +281,71 @@ func TestNewCollection(test *testing.T) {
 
 	assert.Len(test, singleElementCollection.elements, 1, "New collection with a single element don't instance the right value")
 	assert.Exactly(test, singleElementCollection.elements[0], singleElement, "New collection with a single element don't instance the right value")
+
+	duplicatedCollection := NewCollection([]int{1, 2, 2, 3})
+
+	assert.Equal(test, []Element{1, 2}, duplicatedCollection.Elements(), "New collection should keep the elements before the first duplicated one")
+}
+
+func TestSetMethodWithDuplicatedElement(test *testing.T) {
+	collection := NewCollection([]string{"a", "b"})
+
+	assert.Equal(test, ErrDuplicatedElement, collection.Set(0, "b"), "Set method should keep the elements unique")
+	assert.Equal(test, []Element{"a", "b"}, collection.Elements(), "Duplicated elements shouldn't be set")
+	assert.Nil(test, collection.Set(1, "b"), "Setting the same element in its position shouldn't return an error")
+}
+
+func TestTryFirstAndTryLastMethods(test *testing.T) {
+	collection := NewEmptyCollection()
+
+	_, err := collection.TryFirst()
+	assert.Equal(test, ErrEmptyCollection, err, "TryFirst method should return an error on empty collections")
+
+	_, err = collection.TryLast()
+	assert.Equal(test, ErrEmptyCollection, err, "TryLast method should return an error on empty collections")
+
+	collection.AddRange([]int{1, 2, 3})
+
+	first, err := collection.TryFirst()
+	assert.Nil(test, err, "Unexpected error on TryFirst method")
+	assert.Equal(test, 1, first, "Wrong element returned by TryFirst method")
+
+	last, err := collection.TryLast()
+	assert.Nil(test, err, "Unexpected error on TryLast method")
+	assert.Equal(test, 3, last, "Wrong element returned by TryLast method")
+}
+
+func TestGetMethod(test *testing.T) {
+	collection := NewCollection([]int{1, 2, 3})
+
+	element, err := collection.Get(1)
+	assert.Nil(test, err, "Unexpected error on Get method")
+	assert.Equal(test, 2, element, "Wrong element returned by Get method")
+
+	for _, position := range []int{-1, 3} {
+		_, err = collection.Get(position)
+		assert.Equal(test, ErrIndexOutOfRange, err, "Get method should return an error for position %d", position)
+	}
+}
+
+func TestTryExtractMethod(test *testing.T) {
+	collection := NewCollection([]int{1})
+
+	element, err := collection.TryExtract()
+	assert.Nil(test, err, "Unexpected error on TryExtract method")
+	assert.Equal(test, 1, element, "Wrong element returned by TryExtract method")
+
+	_, err = collection.TryExtract()
+	assert.Equal(test, ErrEmptyCollection, err, "TryExtract method should return an error on empty collections")
+}
+
+func TestSetAtMethod(test *testing.T) {
+	collection := NewCollection([]int{1, 2})
+
+	assert.Nil(test, collection.SetAt(1, 5), "Unexpected error on SetAt method")
+	assert.Equal(test, []Element{1, 5}, collection.Elements(), "SetAt method doesn't works properly")
+	assert.Equal(test, ErrIndexOutOfRange, collection.SetAt(2, 3), "SetAt method should return an error for positions out of range")
+	assert.Equal(test, ErrIndexOutOfRange, NewEmptyCollection().SetAt(0, 3), "SetAt method should return an error on empty collections")
+	assert.Equal(test, ErrInvalidElementType, collection.SetAt(0, "a"), "SetAt method should return an error for invalid types")
+	assert.Equal(test, ErrDuplicatedElement, collection.SetAt(0, 5), "SetAt method should return an error for duplicated elements")
 }
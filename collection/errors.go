@@ -10,7 +10,13 @@
 
 package collection
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/jaimelopez/datatypes/internal/codec"
+)
 
 // ErrInvalidElementType represents an error for invalid element type
 var ErrInvalidElementType = errors.New("Invalid element type: collection must to be homogeneous")
@@ -20,3 +26,99 @@ var ErrDuplicatedElement = errors.New("Duplicated element in collection")
 
 // ErrElementNotFound represents an error for not found elements
 var ErrElementNotFound = errors.New("Element not found")
+
+// ErrEmptyCollection represents an error for accessing elements of an empty collection
+var ErrEmptyCollection = errors.New("Empty collection: there are no elements")
+
+// ErrIndexOutOfRange represents an error for positions which don't exist in the collection
+var ErrIndexOutOfRange = errors.New("Index out of range")
+
+// ErrInvalidCount represents an error for a non-positive number of occurrences
+var ErrInvalidCount = errors.New("Invalid count: the number of occurrences must be greater than zero")
+
+// ErrNonOrderedElement represents an error for elements which can't be sorted without a comparator
+var ErrNonOrderedElement = errors.New("Non-ordered element type: a comparator must be specified")
+
+// ErrInvalidScanSource represents an error for database values which can't be scanned into a collection
+var ErrInvalidScanSource = errors.New("Invalid database value: it must be a JSON array or a Postgres array literal")
+
+// ErrTransactionClosed represents an error for transactions already committed or rolled back
+var ErrTransactionClosed = errors.New("Transaction has already been committed or rolled back")
+
+// DecodeError represents an error decoding an element of a collection
+// It keeps the position of the offending element and the underlying error,
+// so it can be checked with errors.Is against the rest of errors of the package
+type DecodeError struct {
+	Index int
+	Err   error
+}
+
+func (err *DecodeError) Error() string {
+	return fmt.Sprintf("Element at index %d: %s", err.Index, err.Err)
+}
+
+// Unwrap returns the underlying error
+func (err *DecodeError) Unwrap() error {
+	return err.Err
+}
+
+// TypeMismatchError represents an error for an element of a range whose type
+// doesn't match the type of the collection
+// It's considered equal to ErrInvalidElementType by errors.Is
+type TypeMismatchError struct {
+	Expected reflect.Type
+	Actual   reflect.Type
+	Index    int
+}
+
+func (err *TypeMismatchError) Error() string {
+	return fmt.Sprintf("Element at index %d: %s: expected %v but got %v", err.Index, ErrInvalidElementType, err.Expected, err.Actual)
+}
+
+// Is reports if the target is ErrInvalidElementType
+func (err *TypeMismatchError) Is(target error) bool {
+	return target == ErrInvalidElementType
+}
+
+// DuplicateError represents an error for an element of a range which is
+// already stored in the collection or repeated in the range itself
+// It's considered equal to ErrDuplicatedElement by errors.Is
+type DuplicateError struct {
+	Value Element
+	Index int
+}
+
+func (err *DuplicateError) Error() string {
+	return fmt.Sprintf("Element at index %d: %s: %v", err.Index, ErrDuplicatedElement, err.Value)
+}
+
+// Is reports if the target is ErrDuplicatedElement
+func (err *DuplicateError) Is(target error) bool {
+	return target == ErrDuplicatedElement
+}
+
+// NotFoundError represents an error for an element of a range which isn't
+// stored in the collection or is repeated in the range itself
+// It's considered equal to ErrElementNotFound by errors.Is
+type NotFoundError struct {
+	Value Element
+	Index int
+}
+
+func (err *NotFoundError) Error() string {
+	return fmt.Sprintf("Element at index %d: %s: %v", err.Index, ErrElementNotFound, err.Value)
+}
+
+// Is reports if the target is ErrElementNotFound
+func (err *NotFoundError) Is(target error) bool {
+	return target == ErrElementNotFound
+}
+
+// ErrCorruptedData represents an error for malformed, truncated or tampered binary data
+var ErrCorruptedData = codec.ErrCorrupted
+
+// ErrUnsupportedVersion represents an error for binary data written by an unknown version of the format
+var ErrUnsupportedVersion = codec.ErrUnsupportedVersion
+
+// ErrUnsupportedType represents an error for element types which the binary format can't represent
+var ErrUnsupportedType = codec.ErrUnsupportedType
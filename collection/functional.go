@@ -0,0 +1,214 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// The datatypes/collection package provides new structures and
+// behaviours to the iteration of non-sorted unique element and homogeneous
+// lists accepting primitives types and complex user structs as well.
+
+// This part of package contains the functional operations over collections
+
+package collection
+
+import "github.com/jaimelopez/datatypes/generic"
+
+// Pair represents two elements coming from different collections
+type Pair struct {
+	First  Element
+	Second Element
+}
+
+// Where returns a new collection with the elements which satisfy the specified function
+// It's the chainable version of Filter
+func (col *Collection) Where(f func(Element) bool) *Collection {
+	return newDerivedCollection(col.Filter(f))
+}
+
+// Map returns a new collection with the results of applying the function to every element
+// Since the collection is unique, repeated results are stored only once
+// If the results are not homogeneous it returns an error
+func (col *Collection) Map(f func(Element) Element) (*Collection, error) {
+	collection := NewEmptyCollection()
+
+	for _, element := range col.elements {
+		if err := collection.addDistinct(f(element)); err != nil {
+			return nil, err
+		}
+	}
+
+	return collection, nil
+}
+
+// FlatMap returns a new collection with all the elements of the lists returned
+// by the function for every element
+// Since the collection is unique, repeated results are stored only once
+// If the results are not homogeneous or they're not iterable it returns an error
+func (col *Collection) FlatMap(f func(Element) ElementList) (*Collection, error) {
+	collection := NewEmptyCollection()
+
+	for _, element := range col.elements {
+		slice, err := generic.ToSlice(f(element))
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, current := range slice {
+			if err = collection.addDistinct(current); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return collection, nil
+}
+
+// Reduce combines all the elements using the specified function, starting with the first one
+// If the collection is empty it returns nil
+func (col *Collection) Reduce(f func(accumulated Element, element Element) Element) Element {
+	if col.IsEmpty() {
+		return nil
+	}
+
+	return col.Skip(1).Fold(col.First(), f)
+}
+
+// Fold combines all the elements using the specified function, starting with the initial value
+func (col *Collection) Fold(initial Element, f func(accumulated Element, element Element) Element) Element {
+	accumulated := initial
+
+	for _, element := range col.elements {
+		accumulated = f(accumulated, element)
+	}
+
+	return accumulated
+}
+
+// Partition splits the collection in two new ones: the elements which satisfy
+// the specified function and the ones which don't
+func (col *Collection) Partition(f func(Element) bool) (*Collection, *Collection) {
+	var matches, rest []Element
+
+	for _, element := range col.elements {
+		if f(element) {
+			matches = append(matches, element)
+		} else {
+			rest = append(rest, element)
+		}
+	}
+
+	return newDerivedCollection(matches), newDerivedCollection(rest)
+}
+
+// Distinct returns a new collection keeping only the first element of every group
+// of elements for which the specified function returns the same key
+func (col *Collection) Distinct(f func(Element) Element) *Collection {
+	keys := NewEmptyCollection()
+
+	return col.Where(func(element Element) bool {
+		return keys.Add(f(element)) == nil
+	})
+}
+
+// Any checks if at least one element satisfies the specified function
+func (col *Collection) Any(f func(Element) bool) bool {
+	for _, element := range col.elements {
+		if f(element) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// All checks if all the elements satisfy the specified function
+func (col *Collection) All(f func(Element) bool) bool {
+	return !col.Any(func(element Element) bool { return !f(element) })
+}
+
+// None checks if none of the elements satisfies the specified function
+func (col *Collection) None(f func(Element) bool) bool {
+	return !col.Any(f)
+}
+
+// Count returns the number of elements which satisfy the specified function
+func (col *Collection) Count(f func(Element) bool) int {
+	return len(col.Filter(f))
+}
+
+// Find returns the first element which satisfies the specified function
+// If there is no such element it returns an error
+func (col *Collection) Find(f func(Element) bool) (Element, error) {
+	for _, element := range col.elements {
+		if f(element) {
+			return element, nil
+		}
+	}
+
+	return nil, ErrElementNotFound
+}
+
+// Take returns a new collection with the first n elements
+func (col *Collection) Take(n int) *Collection {
+	n = bound(n, col.Size())
+
+	return newDerivedCollection(append([]Element(nil), col.elements[:n]...))
+}
+
+// Skip returns a new collection without the first n elements
+func (col *Collection) Skip(n int) *Collection {
+	n = bound(n, col.Size())
+
+	return newDerivedCollection(append([]Element(nil), col.elements[n:]...))
+}
+
+// Chunk splits the collection in new collections of the specified size
+// The last one could have less elements
+func (col *Collection) Chunk(size int) []*Collection {
+	var chunks []*Collection
+
+	if size <= 0 {
+		return chunks
+	}
+
+	for start := 0; start < col.Size(); start += size {
+		end := bound(start+size, col.Size())
+		chunks = append(chunks, newDerivedCollection(append([]Element(nil), col.elements[start:end]...)))
+	}
+
+	return chunks
+}
+
+// Zip returns a new collection of Pair combining the elements of both collections
+// in the same position. The result has the size of the smallest collection
+func (col *Collection) Zip(collection *Collection) *Collection {
+	var pairs []Element
+
+	for position := 0; position < col.Size() && position < collection.Size(); position++ {
+		pairs = append(pairs, Pair{col.elements[position], collection.elements[position]})
+	}
+
+	return newDerivedCollection(pairs)
+}
+
+// addDistinct adds the element ignoring it if it's already contained
+func (col *Collection) addDistinct(element Element) error {
+	if err := col.Add(element); err != nil && err != ErrDuplicatedElement {
+		return err
+	}
+
+	return nil
+}
+
+// bound limits the number between zero and the specified maximum
+func bound(number int, max int) int {
+	if number < 0 {
+		return 0
+	}
+
+	if number > max {
+		return max
+	}
+
+	return number
+}
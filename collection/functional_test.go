@@ -0,0 +1,129 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// The datatypes/collection package provides new structures and
+// behaviours to the iteration of non-sorted unique element and homogeneous
+// lists accepting primitives types and complex user structs as well.
+
+// This part of package contains the tests for the functional operations
+
+package collection
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func isEven(element Element) bool {
+	return element.(int)%2 == 0
+}
+
+func TestWhereMethod(test *testing.T) {
+	collection := NewCollection([]int{1, 2, 3, 4})
+
+	assert.Equal(test, []Element{2, 4}, collection.Where(isEven).Elements(), "Wrong elements on Where method")
+	assert.Nil(test, collection.Where(isEven).Add(6), "Collections returned by Where method should keep the type definition")
+}
+
+func TestMapMethods(test *testing.T) {
+	collection := NewCollection([]int{1, 2, 3})
+
+	mapped, err := collection.Map(func(element Element) Element { return strconv.Itoa(element.(int) % 2) })
+
+	assert.Nil(test, err, "Unexpected error on Map method")
+	assert.Equal(test, []Element{"1", "0"}, mapped.Elements(), "Map method should keep the results unique")
+
+	_, err = collection.Map(func(element Element) Element {
+		if isEven(element) {
+			return element
+		}
+
+		return strconv.Itoa(element.(int))
+	})
+
+	assert.Equal(test, ErrInvalidElementType, err, "Non-homogeneous results should return an error on Map method")
+
+	flattened, err := NewCollection([]string{"a b", "b c"}).FlatMap(func(element Element) ElementList {
+		return strings.Split(element.(string), " ")
+	})
+
+	assert.Nil(test, err, "Unexpected error on FlatMap method")
+	assert.Equal(test, []Element{"a", "b", "c"}, flattened.Elements(), "Wrong elements on FlatMap method")
+}
+
+func TestReduceMethods(test *testing.T) {
+	collection := NewCollection([]int{1, 2, 3, 4})
+	sum := func(accumulated Element, element Element) Element { return accumulated.(int) + element.(int) }
+
+	assert.Equal(test, 10, collection.Reduce(sum), "Wrong result on Reduce method")
+	assert.Equal(test, 15, collection.Fold(5, sum), "Wrong result on Fold method")
+	assert.Nil(test, NewEmptyCollection().Reduce(sum), "Reducing an empty collection should return nil")
+}
+
+func TestPartitionMethod(test *testing.T) {
+	even, odd := NewCollection([]int{1, 2, 3, 4}).Partition(isEven)
+
+	assert.Equal(test, []Element{2, 4}, even.Elements(), "Wrong matching elements on Partition method")
+	assert.Equal(test, []Element{1, 3}, odd.Elements(), "Wrong non-matching elements on Partition method")
+}
+
+func TestDistinctMethod(test *testing.T) {
+	collection := NewCollection([]string{"apple", "avocado", "banana"})
+
+	distinct := collection.Distinct(func(element Element) Element { return element.(string)[0] })
+
+	assert.Equal(test, []Element{"apple", "banana"}, distinct.Elements(), "Distinct method should keep the first element of every key")
+}
+
+func TestPredicateMethods(test *testing.T) {
+	collection := NewCollection([]int{1, 2, 3, 4})
+
+	assert.True(test, collection.Any(isEven), "Any return a false negative")
+	assert.False(test, collection.All(isEven), "All return a false positive")
+	assert.True(test, NewCollection([]int{2, 4}).All(isEven), "All return a false negative")
+	assert.True(test, NewCollection([]int{1, 3}).None(isEven), "None return a false negative")
+	assert.Equal(test, 2, collection.Count(isEven), "Wrong result on Count method")
+
+	found, err := collection.Find(isEven)
+
+	assert.Nil(test, err, "Unexpected error on Find method")
+	assert.Equal(test, 2, found, "Find method should return the first matching element")
+
+	_, err = NewCollection([]int{1}).Find(isEven)
+
+	assert.Equal(test, ErrElementNotFound, err, "Find method should return an error without matching elements")
+}
+
+func TestSliceMethods(test *testing.T) {
+	collection := NewCollection([]int{1, 2, 3, 4, 5})
+
+	assert.Equal(test, []Element{1, 2}, collection.Take(2).Elements(), "Wrong elements on Take method")
+	assert.Equal(test, []Element{4, 5}, collection.Skip(3).Elements(), "Wrong elements on Skip method")
+	assert.Equal(test, 5, collection.Take(10).Size(), "Take method should be bounded to the collection size")
+	assert.True(test, collection.Skip(10).IsEmpty(), "Skip method should be bounded to the collection size")
+
+	chunks := collection.Chunk(2)
+
+	assert.Len(test, chunks, 3, "Wrong number of chunks on Chunk method")
+	assert.Equal(test, []Element{5}, chunks[2].Elements(), "Last chunk should contain the remaining elements")
+}
+
+func TestZipMethod(test *testing.T) {
+	zipped := NewCollection([]int{1, 2, 3}).Zip(NewCollection([]string{"a", "b"}))
+
+	assert.Equal(test, []Element{Pair{1, "a"}, Pair{2, "b"}}, zipped.Elements(), "Wrong elements on Zip method")
+}
+
+func TestPipeline(test *testing.T) {
+	collection := NewCollection([]int{1, 2, 3, 4, 5, 6, 7, 8})
+
+	result := collection.Where(isEven).Skip(1).Take(2).Fold(0, func(accumulated Element, element Element) Element {
+		return accumulated.(int) + element.(int)
+	})
+
+	assert.Equal(test, 10, result, "Wrong result composing a pipeline")
+}
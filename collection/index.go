@@ -0,0 +1,133 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// The datatypes/collection package provides new structures and
+// behaviours to the iteration of non-sorted unique element and homogeneous
+// lists accepting primitives types and complex user structs as well.
+
+// This part of package contains the hash index used to look up the elements
+
+package collection
+
+import (
+	"reflect"
+
+	"github.com/jaimelopez/datatypes/generic"
+)
+
+// index keeps track of the elements stored in a collection so they
+// can be looked up in constant time instead of walking the whole list
+// Elements whose type can be compared with == are used directly as map keys,
+// otherwise they're bucketed by their structural hash and compared with generic.Equal.
+// It counts the occurrences of every element, so it can back a bag as well
+type index struct {
+	direct  bool
+	counts  map[Element]int
+	buckets map[uint64][]*indexEntry
+}
+
+type indexEntry struct {
+	element Element
+	count   int
+}
+
+func (idx *index) add(element Element) {
+	idx.addN(element, 1)
+}
+
+func (idx *index) addN(element Element, n int) {
+	if idx.direct {
+		idx.counts[element] += n
+
+		return
+	}
+
+	if entry := idx.entry(element); entry != nil {
+		entry.count += n
+
+		return
+	}
+
+	hash := generic.Hash(element)
+	idx.buckets[hash] = append(idx.buckets[hash], &indexEntry{element: element, count: n})
+}
+
+func (idx *index) remove(element Element) {
+	idx.removeN(element, 1)
+}
+
+func (idx *index) removeN(element Element, n int) {
+	if idx.direct {
+		if idx.counts[element] <= n {
+			delete(idx.counts, element)
+		} else {
+			idx.counts[element] -= n
+		}
+
+		return
+	}
+
+	hash := generic.Hash(element)
+	bucket := idx.buckets[hash]
+
+	for position, current := range bucket {
+		if !generic.Equal(current.element, element) {
+			continue
+		}
+
+		if current.count > n {
+			current.count -= n
+		} else if len(bucket) == 1 {
+			delete(idx.buckets, hash)
+		} else {
+			idx.buckets[hash] = append(bucket[:position:position], bucket[position+1:]...)
+		}
+
+		return
+	}
+}
+
+func (idx *index) contains(element Element) bool {
+	return idx.count(element) > 0
+}
+
+// count returns the number of occurrences of the element
+func (idx *index) count(element Element) int {
+	if idx.direct {
+		return idx.counts[element]
+	}
+
+	if entry := idx.entry(element); entry != nil {
+		return entry.count
+	}
+
+	return 0
+}
+
+// entry returns the bucket entry of the element or nil if it's not indexed
+func (idx *index) entry(element Element) *indexEntry {
+	for _, current := range idx.buckets[generic.Hash(element)] {
+		if generic.Equal(current.element, element) {
+			return current
+		}
+	}
+
+	return nil
+}
+
+func (idx *index) equal(first Element, second Element) bool {
+	if idx.direct {
+		return first == second
+	}
+
+	return generic.Equal(first, second)
+}
+
+func newIndex(definition reflect.Type) *index {
+	if generic.IsComparable(definition) {
+		return &index{direct: true, counts: make(map[Element]int)}
+	}
+
+	return &index{buckets: make(map[uint64][]*indexEntry)}
+}
@@ -0,0 +1,212 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// The datatypes/collection package provides new structures and
+// behaviours to the iteration of non-sorted unique element and homogeneous
+// lists accepting primitives types and complex user structs as well.
+
+// This part of package contains the tests and benchmarks for the hash index
+
+package collection
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type indexedElement struct {
+	ID   int
+	Tags []string
+}
+
+func TestIndexWithComparableElements(test *testing.T) {
+	collection := NewCollection([]int{1, 2, 3})
+
+	assert.True(test, collection.index.direct, "Comparable elements should be indexed directly")
+	assert.True(test, collection.Contains(2), "Contains return a false negative with indexed elements")
+	assert.Error(test, collection.Add(2), ErrDuplicatedElement, "Duplicated indexed elements should return an error")
+	assert.False(test, collection.Contains("2"), "Contains return a false positive with non-homogeneous elements")
+	assert.False(test, collection.Contains([]int{2}), "Contains return a false positive with non-comparable elements")
+
+	collection.Set(1, 4)
+
+	assert.False(test, collection.Contains(2), "Replaced elements should be removed from the index")
+	assert.True(test, collection.Contains(4), "New elements should be added to the index on Set method")
+
+	collection.Extract()
+
+	assert.False(test, collection.Contains(1), "Extracted elements should be removed from the index")
+	assert.Equal(test, []Element{4, 3}, collection.Elements(), "Insertion order should be kept")
+}
+
+func TestIndexWithNonComparableElements(test *testing.T) {
+	elementOne := indexedElement{1, []string{"a"}}
+	elementTwo := indexedElement{2, []string{"b"}}
+
+	collection := NewCollection([]indexedElement{elementOne, elementTwo})
+
+	assert.False(test, collection.index.direct, "Non-comparable elements should be indexed by hash")
+	assert.True(test, collection.Contains(indexedElement{1, []string{"a"}}), "Contains return a false negative with deeply equal elements")
+	assert.Error(test, collection.Add(indexedElement{2, []string{"b"}}), ErrDuplicatedElement, "Duplicated deeply equal elements should return an error")
+	assert.Nil(test, collection.Delete(indexedElement{1, []string{"a"}}), "Unexpected error deleting a deeply equal element")
+	assert.False(test, collection.Contains(elementOne), "Deleted elements should be removed from the index")
+	assert.Equal(test, []Element{elementTwo}, collection.Elements(), "Wrong remained elements after deletion")
+}
+
+func TestIndexCounts(test *testing.T) {
+	for _, idx := range []*index{newIndex(reflect.TypeOf(0)), newIndex(reflect.TypeOf([]int{}))} {
+		element := Element(1)
+
+		if !idx.direct {
+			element = []int{1}
+		}
+
+		idx.addN(element, 3)
+		idx.add(element)
+
+		assert.Equal(test, 4, idx.count(element), "Index should count the occurrences of the elements")
+
+		idx.removeN(element, 3)
+
+		assert.True(test, idx.contains(element), "Index should keep the elements with remaining occurrences")
+
+		idx.remove(element)
+
+		assert.False(test, idx.contains(element), "Index should drop the elements without occurrences")
+		assert.Equal(test, 0, idx.count(element), "Dropped elements shouldn't be counted")
+	}
+}
+
+func TestIndexWithPointerElements(test *testing.T) {
+	first := &indexedElement{ID: 1}
+
+	collection := NewCollection([]*indexedElement{first})
+
+	assert.False(test, collection.index.direct, "Pointers should be indexed by hash")
+	assert.True(test, collection.Contains(&indexedElement{ID: 1}), "Pointers should be compared by their pointed values as reflect.DeepEqual does")
+	assert.Equal(test, ErrDuplicatedElement, collection.Add(&indexedElement{ID: 1}), "Pointers to deeply equal values should be duplicated")
+	assert.ErrorIs(test, NewEmptyCollection().AddRange([]*indexedElement{{ID: 1}, {ID: 1}}), ErrDuplicatedElement, "Pointers to deeply equal values in a range should be duplicated")
+	assert.Nil(test, collection.Delete(&indexedElement{ID: 1}), "Unexpected error deleting a deeply equal pointer")
+	assert.True(test, collection.IsEmpty(), "Deeply equal pointers should be deleted")
+}
+
+func TestIndexWithPointerFields(test *testing.T) {
+	type element struct {
+		Name   string
+		Parent *indexedElement
+	}
+
+	collection := NewCollection([]element{{"a", &indexedElement{ID: 1}}})
+
+	assert.False(test, collection.index.direct, "Structs with pointer fields should be indexed by hash")
+	assert.True(test, collection.Contains(element{"a", &indexedElement{ID: 1}}), "Pointer fields should be compared by their pointed values")
+	assert.False(test, collection.Contains(element{"a", &indexedElement{ID: 2}}), "Contains return a false positive with different pointed values")
+}
+
+func TestIndexIsRebuiltAfterEmptying(test *testing.T) {
+	collection := NewCollection([]int{1})
+	collection.Extract()
+
+	assert.Nil(test, collection.Add([]int{1}), "Unexpected error adding a new type of element to an empty collection")
+	assert.True(test, collection.Contains([]int{1}), "Index should be rebuilt with the new type definition")
+}
+
+// linearCollection mimics the collection behaviour before the hash index
+// was introduced in order to compare the performance of both
+type linearCollection struct {
+	definition reflect.Type
+	elements   []Element
+}
+
+func (col *linearCollection) add(element Element) error {
+	if len(col.elements) == 0 {
+		col.definition = reflect.TypeOf(element)
+	} else if col.definition != reflect.TypeOf(element) {
+		return ErrInvalidElementType
+	}
+
+	for _, current := range col.elements {
+		if reflect.DeepEqual(current, element) {
+			return ErrDuplicatedElement
+		}
+	}
+
+	col.elements = append(col.elements, element)
+
+	return nil
+}
+
+const benchmarkSize = 5000
+
+func benchmarkElements() []int {
+	elements := make([]int, benchmarkSize)
+
+	for x := range elements {
+		elements[x] = x
+	}
+
+	return elements
+}
+
+func BenchmarkAddRangeLinear(benchmark *testing.B) {
+	elements := benchmarkElements()
+
+	for x := 0; x < benchmark.N; x++ {
+		collection := new(linearCollection)
+
+		for _, element := range elements {
+			collection.add(element)
+		}
+	}
+}
+
+func BenchmarkAddRangeIndexed(benchmark *testing.B) {
+	elements := benchmarkElements()
+
+	for x := 0; x < benchmark.N; x++ {
+		NewCollection(elements)
+	}
+}
+
+func BenchmarkAddRangeIndexedByHash(benchmark *testing.B) {
+	elements := make([]indexedElement, benchmarkSize)
+
+	for x := range elements {
+		elements[x] = indexedElement{ID: x}
+	}
+
+	for x := 0; x < benchmark.N; x++ {
+		NewCollection(elements)
+	}
+}
+
+func BenchmarkContainsLinear(benchmark *testing.B) {
+	collection := new(linearCollection)
+
+	for _, element := range benchmarkElements() {
+		collection.add(element)
+	}
+
+	benchmark.ResetTimer()
+
+	for x := 0; x < benchmark.N; x++ {
+		for _, current := range collection.elements {
+			if reflect.DeepEqual(current, benchmarkSize-1) {
+				break
+			}
+		}
+	}
+}
+
+func BenchmarkContainsIndexed(benchmark *testing.B) {
+	collection := NewCollection(benchmarkElements())
+
+	benchmark.ResetTimer()
+
+	for x := 0; x < benchmark.N; x++ {
+		collection.Contains(benchmarkSize - 1)
+	}
+}
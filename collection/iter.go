@@ -0,0 +1,50 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// The datatypes/collection package provides new structures and
+// behaviours to the iteration of non-sorted unique element and homogeneous
+// lists accepting primitives types and complex user structs as well.
+
+// This part of package contains the lazy iterators over collections
+
+package collection
+
+import "iter"
+
+// Iter returns an iterator over the positions and elements of the collection
+// Unlike Elements, it doesn't expose the internal storage of the collection
+func (col *Collection) Iter() iter.Seq2[int, Element] {
+	return func(yield func(int, Element) bool) {
+		for position, element := range col.elements {
+			if !yield(position, element) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the positions and elements of the collection
+// walking it from the last element to the first one
+func (col *Collection) Backward() iter.Seq2[int, Element] {
+	return func(yield func(int, Element) bool) {
+		elements := col.elements
+
+		for position := len(elements) - 1; position >= 0; position-- {
+			if !yield(position, elements[position]) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over the elements of the collection
+func (col *Collection) Values() iter.Seq[Element] {
+	return func(yield func(Element) bool) {
+		for _, element := range col.elements {
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// The datatypes/collection package provides new structures and
+// behaviours to the iteration of non-sorted unique element and homogeneous
+// lists accepting primitives types and complex user structs as well.
+
+// This part of package contains the tests for the lazy iterators
+
+package collection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIterMethod(test *testing.T) {
+	collection := NewCollection([]string{"a", "b", "c"})
+	visited := map[int]Element{}
+
+	for position, element := range collection.Iter() {
+		visited[position] = element
+	}
+
+	assert.Equal(test, map[int]Element{0: "a", 1: "b", 2: "c"}, visited, "Iter should visit every position and element")
+
+	for position := range collection.Iter() {
+		if position == 0 {
+			break
+		}
+
+		test.Error("Iter should stop when the loop breaks")
+	}
+}
+
+func TestBackwardMethod(test *testing.T) {
+	var elements []Element
+
+	for _, element := range NewCollection([]string{"a", "b", "c"}).Backward() {
+		elements = append(elements, element)
+	}
+
+	assert.Equal(test, []Element{"c", "b", "a"}, elements, "Backward should visit the elements in reverse order")
+}
+
+func TestValuesMethod(test *testing.T) {
+	var elements []Element
+
+	for element := range NewCollection([]int{1, 2, 3}).Values() {
+		if element == 3 {
+			break
+		}
+
+		elements = append(elements, element)
+	}
+
+	assert.Equal(test, []Element{1, 2}, elements, "Values should visit the elements until the loop breaks")
+}
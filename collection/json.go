@@ -0,0 +1,79 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// The datatypes/collection package provides new structures and
+// behaviours to the iteration of non-sorted unique element and homogeneous
+// lists accepting primitives types and complex user structs as well.
+
+// This part of package contains the JSON encoding and decoding of collections
+
+package collection
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// MarshalJSON encodes the collection as a JSON array keeping the order of the elements
+func (col *Collection) MarshalJSON() ([]byte, error) {
+	if col.IsEmpty() {
+		return []byte("[]"), nil
+	}
+
+	return json.Marshal(col.elements)
+}
+
+// UnmarshalJSON decodes a JSON array replacing the elements of the collection
+// The decoded elements must be unique and homogeneous, otherwise it returns a
+// *DecodeError wrapping ErrDuplicatedElement or ErrInvalidElementType with the
+// position of the offending element, and the collection is left untouched.
+// Elements are decoded as the standard library does for interface{} values
+// unless a concrete type has been registered with DecodeAs
+func (col *Collection) UnmarshalJSON(data []byte) error {
+	var raws []json.RawMessage
+
+	if err := json.Unmarshal(data, &raws); err != nil {
+		return err
+	}
+
+	elements := make([]Element, 0, len(raws))
+
+	for position, raw := range raws {
+		element, err := col.decode(raw)
+
+		if err != nil {
+			return &DecodeError{Index: position, Err: err}
+		}
+
+		elements = append(elements, element)
+	}
+
+	return col.replace(elements)
+}
+
+// DecodeAs registers the type of the specified prototype as the type
+// which the elements will be decoded into by UnmarshalJSON and UnmarshalBinary
+// It returns the same collection so it can be chained
+func (col *Collection) DecodeAs(prototype Element) *Collection {
+	col.decoding = reflect.TypeOf(prototype)
+
+	return col
+}
+
+func (col *Collection) decode(raw json.RawMessage) (Element, error) {
+	if col.decoding == nil {
+		var element Element
+		err := json.Unmarshal(raw, &element)
+
+		return element, err
+	}
+
+	element := reflect.New(col.decoding)
+
+	if err := json.Unmarshal(raw, element.Interface()); err != nil {
+		return nil, err
+	}
+
+	return element.Elem().Interface(), nil
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// The datatypes/collection package provides new structures and
+// behaviours to the iteration of non-sorted unique element and homogeneous
+// lists accepting primitives types and complex user structs as well.
+
+// This part of package contains the tests for the JSON encoding and decoding
+
+package collection
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type jsonTestStruct struct {
+	Name string `json:"name"`
+	Age  int    `json:"age"`
+}
+
+func TestMarshalJSONMethod(test *testing.T) {
+	encoded, err := json.Marshal(NewCollection([]int{3, 1, 2}))
+
+	assert.Nil(test, err, "Unexpected error encoding a collection")
+	assert.JSONEq(test, `[3, 1, 2]`, string(encoded), "Wrong encoded collection")
+
+	encoded, _ = json.Marshal(NewEmptyCollection())
+
+	assert.Equal(test, `[]`, string(encoded), "Empty collections should be encoded as an empty array")
+
+	wrapped, _ := json.Marshal(struct{ Items *Collection }{NewCollection([]string{"a"})})
+
+	assert.JSONEq(test, `{"Items": ["a"]}`, string(wrapped), "Wrong encoded collection as a struct field")
+}
+
+func TestUnmarshalJSONMethod(test *testing.T) {
+	collection := NewEmptyCollection()
+
+	assert.Nil(test, json.Unmarshal([]byte(`["a", "b"]`), collection), "Unexpected error decoding a collection")
+	assert.Equal(test, []Element{"a", "b"}, collection.Elements(), "Wrong decoded elements")
+	assert.True(test, collection.Contains("b"), "Decoded collections should be indexed")
+
+	err := json.Unmarshal([]byte(`["a", "b", "a"]`), collection)
+
+	var decodeErr *DecodeError
+
+	assert.True(test, errors.As(err, &decodeErr), "Decoding duplicated elements should return a DecodeError")
+	assert.Equal(test, 2, decodeErr.Index, "Wrong index of the duplicated element")
+	assert.True(test, errors.Is(err, ErrDuplicatedElement), "Decoding duplicated elements should wrap ErrDuplicatedElement")
+
+	err = json.Unmarshal([]byte(`["a", 1]`), collection)
+
+	assert.True(test, errors.Is(err, ErrInvalidElementType), "Decoding heterogeneous elements should wrap ErrInvalidElementType")
+	assert.Equal(test, []Element{"a", "b"}, collection.Elements(), "Failed decodings should leave the collection untouched")
+
+	assert.Error(test, json.Unmarshal([]byte(`{"a": 1}`), collection), "Decoding a non array should return an error")
+}
+
+func TestDecodeAsMethod(test *testing.T) {
+	collection := NewEmptyCollection().DecodeAs(jsonTestStruct{})
+
+	err := json.Unmarshal([]byte(`[{"name": "Jaime", "age": 30}, {"name": "Ana", "age": 25}]`), collection)
+
+	assert.Nil(test, err, "Unexpected error decoding into a registered type")
+	assert.Equal(test, []Element{jsonTestStruct{"Jaime", 30}, jsonTestStruct{"Ana", 25}}, collection.Elements(), "Wrong elements decoding into a registered type")
+
+	err = json.Unmarshal([]byte(`[{"name": "Jaime"}, {"name": 1}]`), collection)
+
+	var decodeErr *DecodeError
+
+	assert.True(test, errors.As(err, &decodeErr), "Wrong typed elements should return a DecodeError")
+	assert.Equal(test, 1, decodeErr.Index, "Wrong index of the wrong typed element")
+
+	pointers := NewEmptyCollection().DecodeAs(&jsonTestStruct{})
+
+	assert.Nil(test, json.Unmarshal([]byte(`[{"name": "Jaime"}]`), pointers), "Unexpected error decoding into pointers")
+	assert.Equal(test, &jsonTestStruct{Name: "Jaime"}, pointers.First(), "Wrong element decoding into pointers")
+}
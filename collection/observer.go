@@ -0,0 +1,106 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// The datatypes/collection package provides new structures and
+// behaviours to the iteration of non-sorted unique element and homogeneous
+// lists accepting primitives types and complex user structs as well.
+
+// This part of package contains the change notifications of collections
+
+package collection
+
+import "github.com/jaimelopez/datatypes/internal/observer"
+
+// EventType represents the kind of change of a collection
+type EventType int
+
+const (
+	// AddEvent is notified when an element is added
+	AddEvent EventType = iota
+	// SetEvent is notified when an element is replaced
+	SetEvent
+	// DeleteEvent is notified when an element is deleted
+	DeleteEvent
+	// ExtractEvent is notified when an element is extracted
+	ExtractEvent
+	// ResetEvent is notified when all the elements are replaced or reordered at once,
+	// like decoding the collection with UnmarshalJSON or Scan or sorting it
+	ResetEvent
+)
+
+// Event represents a change of a collection
+// Old is the element which was stored in the position before the change
+// and New the one stored after it, being nil when they don't apply
+type Event struct {
+	Type     EventType
+	Position int
+	Old      Element
+	New      Element
+}
+
+// Subscription represents a registered listener of a collection
+type Subscription struct {
+	unsubscribe func()
+}
+
+// Unsubscribe stops notifying the listener
+func (subscription *Subscription) Unsubscribe() {
+	subscription.unsubscribe()
+}
+
+// Subscribe registers a listener which is called after every change of the collection
+// The changes done by range operations like AddRange or DeleteRange or by
+// transactions are notified all together once the operation finishes.
+// Listeners are called synchronously by the goroutine which modifies the collection
+func (col *Collection) Subscribe(f func([]Event)) *Subscription {
+	if col.observers == nil {
+		col.observers = new(observer.Hub[Event])
+	}
+
+	return &Subscription{unsubscribe: col.observers.Subscribe(f)}
+}
+
+// OnAdd registers a listener which is called for every added element
+func (col *Collection) OnAdd(f func(Event)) *Subscription {
+	return col.on(AddEvent, f)
+}
+
+// OnSet registers a listener which is called for every replaced element
+func (col *Collection) OnSet(f func(Event)) *Subscription {
+	return col.on(SetEvent, f)
+}
+
+// OnDelete registers a listener which is called for every deleted element
+func (col *Collection) OnDelete(f func(Event)) *Subscription {
+	return col.on(DeleteEvent, f)
+}
+
+// OnExtract registers a listener which is called for every extracted element
+func (col *Collection) OnExtract(f func(Event)) *Subscription {
+	return col.on(ExtractEvent, f)
+}
+
+// OnReset registers a listener which is called every time all the elements are replaced or reordered
+func (col *Collection) OnReset(f func(Event)) *Subscription {
+	return col.on(ResetEvent, f)
+}
+
+func (col *Collection) on(kind EventType, f func(Event)) *Subscription {
+	return col.Subscribe(func(events []Event) {
+		for _, event := range events {
+			if event.Type == kind {
+				f(event)
+			}
+		}
+	})
+}
+
+func (col *Collection) notify(event Event) {
+	col.observers.Notify(event)
+}
+
+// batch groups the notifications until the returned function is called
+func (col *Collection) batch() func() {
+	return col.observers.Batch()
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// The datatypes/collection package provides new structures and
+// behaviours to the iteration of non-sorted unique element and homogeneous
+// lists accepting primitives types and complex user structs as well.
+
+// This part of package contains the tests for the change notifications
+
+package collection
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscribeMethod(test *testing.T) {
+	collection := NewCollection([]string{"a", "b"})
+	received := [][]Event{}
+
+	subscription := collection.Subscribe(func(events []Event) {
+		received = append(received, events)
+	})
+
+	collection.Add("c")
+	collection.Set(0, "z")
+	collection.Delete("b")
+	collection.Extract()
+
+	assert.Equal(test, [][]Event{
+		{{Type: AddEvent, Position: 2, New: "c"}},
+		{{Type: SetEvent, Position: 0, Old: "a", New: "z"}},
+		{{Type: DeleteEvent, Position: 1, Old: "b"}},
+		{{Type: ExtractEvent, Position: 0, Old: "z"}},
+	}, received, "Wrong notified events")
+
+	subscription.Unsubscribe()
+	collection.Add("d")
+
+	assert.Len(test, received, 4, "Unsubscribed listeners shouldn't be notified")
+}
+
+func TestRangeOperationsAreBatched(test *testing.T) {
+	collection := NewEmptyCollection()
+	received := [][]Event{}
+
+	collection.Subscribe(func(events []Event) {
+		received = append(received, events)
+	})
+
+	collection.AddRange([]int{1, 2, 3})
+	collection.DeleteRange([]int{1, 3})
+
+	assert.Equal(test, [][]Event{
+		{{Type: AddEvent, Position: 0, New: 1}, {Type: AddEvent, Position: 1, New: 2}, {Type: AddEvent, Position: 2, New: 3}},
+		{{Type: DeleteEvent, Position: 0, Old: 1}, {Type: DeleteEvent, Position: 1, Old: 3}},
+	}, received, "Range operations should be notified together")
+
+	err := collection.AddRange([]int{4, 2})
+
+	assert.ErrorIs(test, err, ErrDuplicatedElement, "Unexpected result adding duplicated elements")
+
+	collection.AddRange([]int{})
+
+	assert.Len(test, received, 2, "Range operations without changes shouldn't be notified")
+}
+
+func TestTypedListeners(test *testing.T) {
+	collection := NewCollection([]int{1, 2})
+	added, deleted := []Element{}, []Element{}
+
+	collection.OnAdd(func(event Event) { added = append(added, event.New) })
+	subscription := collection.OnDelete(func(event Event) { deleted = append(deleted, event.Old) })
+
+	collection.AddRange([]int{3, 4})
+	collection.Delete(1)
+	subscription.Unsubscribe()
+	collection.Delete(2)
+
+	assert.Equal(test, []Element{3, 4}, added, "Wrong events on OnAdd listener")
+	assert.Equal(test, []Element{1}, deleted, "Wrong events on OnDelete listener")
+
+	sets, extracts := 0, 0
+
+	collection.OnSet(func(Event) { sets++ })
+	collection.OnExtract(func(Event) { extracts++ })
+	collection.Set(0, 5)
+	collection.Extract()
+
+	assert.Equal(test, 1, sets, "Wrong events on OnSet listener")
+	assert.Equal(test, 1, extracts, "Wrong events on OnExtract listener")
+}
+
+func TestSubscriptionsSurviveDecoding(test *testing.T) {
+	collection := NewEmptyCollection()
+	calls := 0
+
+	collection.Subscribe(func([]Event) { calls++ })
+	json.Unmarshal([]byte(`["a"]`), collection)
+	collection.Add("b")
+
+	assert.Equal(test, 2, calls, "Subscriptions should be kept after decoding")
+}
+
+func TestResetEvents(test *testing.T) {
+	collection := NewCollection([]int{1, 2})
+	received := [][]Event{}
+	resets := 0
+
+	collection.Subscribe(func(events []Event) {
+		received = append(received, events)
+	})
+	collection.OnReset(func(Event) { resets++ })
+
+	assert.Nil(test, json.Unmarshal([]byte(`[5,6,7]`), collection), "Unexpected error decoding a collection")
+	assert.Equal(test, [][]Event{{{Type: ResetEvent}}}, received, "Decoding should be notified as a single reset event")
+
+	collection.Sort(func(first Element, second Element) bool { return first.(float64) > second.(float64) })
+
+	assert.Equal(test, 2, resets, "Sorting should be notified as a reset event")
+
+	json.Unmarshal([]byte(`[1,1]`), collection)
+
+	assert.Equal(test, 2, resets, "Failed decodings shouldn't be notified")
+}
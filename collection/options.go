@@ -0,0 +1,50 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// The datatypes/collection package provides new structures and
+// behaviours to the iteration of non-sorted unique element and homogeneous
+// lists accepting primitives types and complex user structs as well.
+
+// This part of package contains the options of the range operations
+
+package collection
+
+import (
+	"reflect"
+
+	"github.com/jaimelopez/datatypes/internal/ranges"
+)
+
+// RangeOption configures the behaviour of a range operation
+type RangeOption = ranges.Option
+
+// JoinErrors makes the range operation check all the elements and return
+// every failure joined with errors.Join instead of stopping at the first one
+func JoinErrors() RangeOption {
+	return ranges.JoinErrors()
+}
+
+// checkElements checks if every element of a range has the specified type and
+// passes the check function, which returns the failure of an element or nil,
+// so a range operation can be validated before applying any change
+// The failures are collected as the options specify
+func checkElements(elements []interface{}, definition reflect.Type, check func(int, Element) error, options []RangeOption) error {
+	failures := ranges.NewFailures(options)
+
+	for position, element := range elements {
+		var err error
+
+		if actual := reflect.TypeOf(element); actual != definition {
+			err = &TypeMismatchError{Expected: definition, Actual: actual, Index: position}
+		} else {
+			err = check(position, element)
+		}
+
+		if err != nil && !failures.Add(err) {
+			break
+		}
+	}
+
+	return failures.Err()
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// The datatypes/collection package provides new structures and
+// behaviours to the iteration of non-sorted unique element and homogeneous
+// lists accepting primitives types and complex user structs as well.
+
+// This part of package contains the tests for the options of the range operations
+
+package collection
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRangeErrors(test *testing.T) {
+	collection := NewCollection([]int{1, 2})
+
+	err := collection.AddRange([]Element{3, "4", 1})
+	mismatch := &TypeMismatchError{Expected: reflect.TypeOf(0), Actual: reflect.TypeOf(""), Index: 1}
+
+	assert.Equal(test, mismatch, err, "AddRange should return the first failure with its context")
+	assert.ErrorIs(test, err, ErrInvalidElementType, "Type mismatches should match ErrInvalidElementType")
+	assert.EqualError(test, err, "Element at index 1: Invalid element type: collection must to be homogeneous: expected int but got string", "Wrong type mismatch message")
+	assert.ErrorIs(test, &DuplicateError{Value: 1}, ErrDuplicatedElement, "Duplicates should match ErrDuplicatedElement")
+	assert.ErrorIs(test, &NotFoundError{Value: 1}, ErrElementNotFound, "Not found elements should match ErrElementNotFound")
+	assert.False(test, errors.Is(mismatch, ErrDuplicatedElement), "Type mismatches shouldn't match other errors")
+}
+
+func TestJoinErrorsOption(test *testing.T) {
+	collection := NewCollection([]int{1, 2})
+
+	err := collection.AddRange([]Element{3, "4", 1, 3}, JoinErrors())
+
+	assert.Equal(test, errors.Join(
+		&TypeMismatchError{Expected: reflect.TypeOf(0), Actual: reflect.TypeOf(""), Index: 1},
+		&DuplicateError{Value: 1, Index: 2},
+		&DuplicateError{Value: 3, Index: 3},
+	), err, "JoinErrors option should return all the failures of AddRange")
+	assert.ErrorIs(test, err, ErrDuplicatedElement, "Joined errors should match every failure")
+	assert.Equal(test, []Element{1, 2}, collection.Elements(), "Failed AddRange calls should leave the collection untouched")
+
+	err = collection.DeleteRange([]int{5, 1, 6}, JoinErrors())
+
+	assert.Equal(test, errors.Join(
+		&NotFoundError{Value: 5, Index: 0},
+		&NotFoundError{Value: 6, Index: 2},
+	), err, "JoinErrors option should return all the failures of DeleteRange")
+	assert.Nil(test, collection.AddRange([]int{3, 4}, JoinErrors()), "JoinErrors option shouldn't return errors without failures")
+}
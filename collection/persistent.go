@@ -0,0 +1,475 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// The datatypes/collection package provides new structures and
+// behaviours to the iteration of non-sorted unique element and homogeneous
+// lists accepting primitives types and complex user structs as well.
+
+// This part of package contains the persistent immutable collection
+
+package collection
+
+import (
+	"iter"
+	"reflect"
+
+	"github.com/jaimelopez/datatypes/generic"
+	"github.com/jaimelopez/datatypes/internal/hamt"
+)
+
+// PersistentCollection represents an immutable collection
+// Modifications return new versions in O(log n) which share most of their
+// structure with the original, so keeping old versions around is cheap.
+// The elements are kept in insertion order in a balanced tree and they're
+// looked up through a hash trie to guarantee their uniqueness
+type PersistentCollection struct {
+	definition reflect.Type
+	root       *sequenceNode
+	members    *hamt.Map
+	next       uint64
+}
+
+// Add returns a new version of the collection with the specified element at the end
+// It follows the same rules than the Add method of Collection
+func (col *PersistentCollection) Add(element Element) (*PersistentCollection, error) {
+	transient := col.Transient()
+
+	if err := transient.Add(element); err != nil {
+		return nil, err
+	}
+
+	return transient.Persistent(), nil
+}
+
+// AddRange returns a new version of the collection with the specified elements at the end
+// It follows the same rules than the AddRange method of Collection
+func (col *PersistentCollection) AddRange(elements ElementList, options ...RangeOption) (*PersistentCollection, error) {
+	transient := col.Transient()
+
+	if err := transient.AddRange(elements, options...); err != nil {
+		return nil, err
+	}
+
+	return transient.Persistent(), nil
+}
+
+// Delete returns a new version of the collection without the specified element
+// It follows the same rules than the Delete method of Collection
+func (col *PersistentCollection) Delete(element Element) (*PersistentCollection, error) {
+	transient := col.Transient()
+
+	if err := transient.Delete(element); err != nil {
+		return nil, err
+	}
+
+	return transient.Persistent(), nil
+}
+
+// First returns the first element or nil if the collection is empty
+func (col *PersistentCollection) First() Element {
+	return col.ElementAt(0)
+}
+
+// Last returns the last element or nil if the collection is empty
+func (col *PersistentCollection) Last() Element {
+	return col.ElementAt(col.Size() - 1)
+}
+
+// ElementAt returns the element in the specified position in O(log n)
+// If the position is out of range it returns nil
+func (col *PersistentCollection) ElementAt(position int) Element {
+	if position < 0 || position >= col.Size() {
+		return nil
+	}
+
+	return col.root.at(position).element
+}
+
+// Elements returns the stored elements as a new slice
+func (col *PersistentCollection) Elements() []Element {
+	elements := make([]Element, 0, col.Size())
+
+	for element := range col.Values() {
+		elements = append(elements, element)
+	}
+
+	return elements
+}
+
+// Iter returns an iterator over the positions and elements of the collection
+func (col *PersistentCollection) Iter() iter.Seq2[int, Element] {
+	return func(yield func(int, Element) bool) {
+		position := 0
+
+		col.root.walk(func(element Element) bool {
+			position++
+
+			return yield(position-1, element)
+		})
+	}
+}
+
+// Values returns an iterator over the elements of the collection
+func (col *PersistentCollection) Values() iter.Seq[Element] {
+	return func(yield func(Element) bool) {
+		col.root.walk(yield)
+	}
+}
+
+// Contains checks if the specified element is already existing in the collection
+func (col *PersistentCollection) Contains(element Element) bool {
+	if col.IsEmpty() || col.definition != reflect.TypeOf(element) {
+		return false
+	}
+
+	_, exists := col.members.Get(element)
+
+	return exists
+}
+
+// Size returns the number of stored elements
+func (col *PersistentCollection) Size() int {
+	return col.root.count()
+}
+
+// IsEmpty checks if the collection is empty or not
+func (col *PersistentCollection) IsEmpty() bool {
+	return col.Size() == 0
+}
+
+// Collection returns a mutable collection with the same elements
+func (col *PersistentCollection) Collection() *Collection {
+	return newDerivedCollection(col.Elements())
+}
+
+// Transient returns a mutable builder initialized with the elements of the collection
+// intended for bulk modifications, which is much faster than creating a new
+// version for every change. The collection itself is never modified
+func (col *PersistentCollection) Transient() *TransientCollection {
+	return &TransientCollection{
+		definition: col.definition,
+		root:       col.root,
+		members:    col.members.Transient(),
+		next:       col.next,
+		owner:      new(owner),
+	}
+}
+
+// TransientCollection represents a mutable builder of persistent collections
+// It modifies in place the structure it owns, so it must not be used concurrently
+type TransientCollection struct {
+	definition reflect.Type
+	root       *sequenceNode
+	members    *hamt.Transient
+	next       uint64
+	owner      *owner
+}
+
+// Add a single element at the end of the collection
+// It follows the same rules than the Add method of Collection
+func (col *TransientCollection) Add(element Element) error {
+	if col.Size() == 0 {
+		col.definition = reflect.TypeOf(element)
+		col.members = newMembers(col.definition).Transient()
+	} else if col.definition != reflect.TypeOf(element) {
+		return ErrInvalidElementType
+	}
+
+	if col.Contains(element) {
+		return ErrDuplicatedElement
+	}
+
+	col.members.Set(element, col.next)
+	col.root = col.root.append(col.owner, col.next, element)
+	col.next++
+
+	return nil
+}
+
+// AddRange inserts a range (slice) at the end of the collection
+// It follows the same rules than the AddRange method of Collection
+func (col *TransientCollection) AddRange(elements ElementList, options ...RangeOption) error {
+	slice, err := generic.ToSlice(elements)
+
+	if err != nil || len(slice) == 0 {
+		return err
+	}
+
+	definition := col.definition
+
+	if col.Size() == 0 {
+		definition = reflect.TypeOf(slice[0])
+	}
+
+	staged := newIndex(definition)
+
+	err = checkElements(slice, definition, func(position int, element Element) error {
+		if col.Size() > 0 && col.Contains(element) || staged.contains(element) {
+			return &DuplicateError{Value: element, Index: position}
+		}
+
+		staged.add(element)
+
+		return nil
+	}, options)
+
+	if err != nil {
+		return err
+	}
+
+	for _, element := range slice {
+		col.Add(element)
+	}
+
+	return nil
+}
+
+// Delete removes an specified already stored element
+// It follows the same rules than the Delete method of Collection
+func (col *TransientCollection) Delete(element Element) error {
+	if col.definition != reflect.TypeOf(element) {
+		return ErrInvalidElementType
+	}
+
+	sequence, exists := col.members.Get(element)
+
+	if !exists {
+		return ErrElementNotFound
+	}
+
+	col.members.Delete(element)
+	col.root = col.root.remove(col.owner, sequence.(uint64))
+
+	return nil
+}
+
+// Contains checks if the specified element is already existing in the collection
+func (col *TransientCollection) Contains(element Element) bool {
+	if col.Size() == 0 || col.definition != reflect.TypeOf(element) {
+		return false
+	}
+
+	_, exists := col.members.Get(element)
+
+	return exists
+}
+
+// Size returns the number of stored elements
+func (col *TransientCollection) Size() int {
+	return col.root.count()
+}
+
+// Persistent returns an immutable collection with the current elements
+// The builder can still be used afterwards without affecting the returned collection
+func (col *TransientCollection) Persistent() *PersistentCollection {
+	col.owner = new(owner)
+
+	return &PersistentCollection{
+		definition: col.definition,
+		root:       col.root,
+		members:    col.members.Persistent(),
+		next:       col.next,
+	}
+}
+
+// owner identifies the transient which is allowed to mutate a node in place
+// It can't be a zero-sized type as their pointers aren't guaranteed to be different
+type owner struct {
+	_ byte
+}
+
+// sequenceNode is a node of a persistent AVL tree which keeps the elements
+// sorted by their insertion sequence and the size of every subtree,
+// so the elements can be accessed by their position
+type sequenceNode struct {
+	sequence uint64
+	element  Element
+	left     *sequenceNode
+	right    *sequenceNode
+	height   int
+	size     int
+	owner    *owner
+}
+
+func (node *sequenceNode) count() int {
+	if node == nil {
+		return 0
+	}
+
+	return node.size
+}
+
+func (node *sequenceNode) depth() int {
+	if node == nil {
+		return 0
+	}
+
+	return node.height
+}
+
+func (node *sequenceNode) at(position int) *sequenceNode {
+	for node != nil {
+		left := node.left.count()
+
+		switch {
+		case position < left:
+			node = node.left
+		case position > left:
+			position -= left + 1
+			node = node.right
+		default:
+			return node
+		}
+	}
+
+	return nil
+}
+
+func (node *sequenceNode) walk(f func(Element) bool) bool {
+	if node == nil {
+		return true
+	}
+
+	return node.left.walk(f) && f(node.element) && node.right.walk(f)
+}
+
+// append returns the tree with the element added as the greatest sequence
+func (node *sequenceNode) append(owner *owner, sequence uint64, element Element) *sequenceNode {
+	if node == nil {
+		return &sequenceNode{sequence: sequence, element: element, height: 1, size: 1, owner: owner}
+	}
+
+	edited := node.editable(owner)
+	edited.right = edited.right.append(owner, sequence, element)
+
+	return edited.balance(owner)
+}
+
+// remove returns the tree without the node of the specified sequence
+func (node *sequenceNode) remove(owner *owner, sequence uint64) *sequenceNode {
+	if node == nil {
+		return nil
+	}
+
+	edited := node.editable(owner)
+
+	switch {
+	case sequence < node.sequence:
+		edited.left = edited.left.remove(owner, sequence)
+	case sequence > node.sequence:
+		edited.right = edited.right.remove(owner, sequence)
+	default:
+		if edited.left == nil {
+			return edited.right
+		}
+
+		if edited.right == nil {
+			return edited.left
+		}
+
+		successor := edited.right
+
+		for successor.left != nil {
+			successor = successor.left
+		}
+
+		edited.sequence, edited.element = successor.sequence, successor.element
+		edited.right = edited.right.remove(owner, successor.sequence)
+	}
+
+	return edited.balance(owner)
+}
+
+func (node *sequenceNode) balance(owner *owner) *sequenceNode {
+	node.update()
+
+	switch factor := node.left.depth() - node.right.depth(); {
+	case factor > 1:
+		if node.left.left.depth() < node.left.right.depth() {
+			node.left = node.left.editable(owner).rotateLeft(owner)
+		}
+
+		return node.rotateRight(owner)
+	case factor < -1:
+		if node.right.right.depth() < node.right.left.depth() {
+			node.right = node.right.editable(owner).rotateRight(owner)
+		}
+
+		return node.rotateLeft(owner)
+	}
+
+	return node
+}
+
+// rotateLeft rotates an editable node whose right child becomes the new root
+func (node *sequenceNode) rotateLeft(owner *owner) *sequenceNode {
+	root := node.right.editable(owner)
+	node.right = root.left
+	root.left = node
+
+	node.update()
+	root.update()
+
+	return root
+}
+
+// rotateRight rotates an editable node whose left child becomes the new root
+func (node *sequenceNode) rotateRight(owner *owner) *sequenceNode {
+	root := node.left.editable(owner)
+	node.left = root.right
+	root.right = node
+
+	node.update()
+	root.update()
+
+	return root
+}
+
+func (node *sequenceNode) update() {
+	node.height = max(node.left.depth(), node.right.depth()) + 1
+	node.size = node.left.count() + node.right.count() + 1
+}
+
+// editable returns the node itself if it's owned by the specified transient,
+// otherwise a copy of it which is owned by the transient
+func (node *sequenceNode) editable(owner *owner) *sequenceNode {
+	if node.owner == owner {
+		return node
+	}
+
+	edited := *node
+	edited.owner = owner
+
+	return &edited
+}
+
+// newMembers instances the hash trie used to look up the elements of the specified type
+func newMembers(definition reflect.Type) *hamt.Map {
+	if generic.IsComparable(definition) {
+		return hamt.New(generic.Hash, func(first interface{}, second interface{}) bool {
+			return first == second
+		})
+	}
+
+	return hamt.New(generic.Hash, generic.Equal)
+}
+
+// Persistent returns an immutable copy of the collection
+func (col *Collection) Persistent() *PersistentCollection {
+	transient := NewEmptyPersistentCollection().Transient()
+	transient.AddRange(col.elements)
+
+	return transient.Persistent()
+}
+
+// NewEmptyPersistentCollection instances a new empty persistent collection
+func NewEmptyPersistentCollection() *PersistentCollection {
+	return &PersistentCollection{members: newMembers(nil)}
+}
+
+// NewPersistentCollection instances a new persistent collection with the specified elements
+// If the parameter can't be converted to a iterable data type it's return an error
+func NewPersistentCollection(elements ElementList) (*PersistentCollection, error) {
+	return NewEmptyPersistentCollection().AddRange(elements)
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// The datatypes/collection package provides new structures and
+// behaviours to the iteration of non-sorted unique element and homogeneous
+// lists accepting primitives types and complex user structs as well.
+
+// This part of package contains the tests for the persistent immutable collection
+
+package collection
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPersistentAddMethod(test *testing.T) {
+	empty := NewEmptyPersistentCollection()
+	first, err := empty.Add(1)
+
+	assert.Nil(test, err, "Unexpected error adding to a persistent collection")
+
+	second, _ := first.Add(2)
+
+	assert.Equal(test, []Element{1, 2}, second.Elements(), "Wrong elements adding to a persistent collection")
+	assert.Equal(test, []Element{1}, first.Elements(), "Previous versions shouldn't be modified")
+	assert.True(test, empty.IsEmpty(), "Previous versions shouldn't be modified")
+
+	_, err = second.Add(1)
+
+	assert.Equal(test, ErrDuplicatedElement, err, "Duplicated elements should return an error")
+
+	_, err = second.Add("1")
+
+	assert.Equal(test, ErrInvalidElementType, err, "Heterogeneous elements should return an error")
+}
+
+func TestPersistentDeleteMethod(test *testing.T) {
+	original, _ := NewPersistentCollection([]string{"a", "b", "c"})
+	deleted, err := original.Delete("b")
+
+	assert.Nil(test, err, "Unexpected error deleting from a persistent collection")
+	assert.Equal(test, []Element{"a", "c"}, deleted.Elements(), "Wrong elements deleting from a persistent collection")
+	assert.Equal(test, []Element{"a", "b", "c"}, original.Elements(), "Previous versions shouldn't be modified")
+	assert.False(test, deleted.Contains("b"), "Deleted elements shouldn't be contained")
+
+	_, err = deleted.Delete("b")
+
+	assert.Equal(test, ErrElementNotFound, err, "Deleting non existing elements should return an error")
+
+	_, err = deleted.Delete(1)
+
+	assert.Equal(test, ErrInvalidElementType, err, "Deleting heterogeneous elements should return an error")
+}
+
+func TestPersistentPositionalAccess(test *testing.T) {
+	collection, _ := NewPersistentCollection([]int{10, 20, 30})
+
+	assert.Equal(test, 10, collection.First(), "Wrong first element")
+	assert.Equal(test, 30, collection.Last(), "Wrong last element")
+	assert.Equal(test, 20, collection.ElementAt(1), "Wrong element at position")
+	assert.Nil(test, collection.ElementAt(3), "Positions out of range should return nil")
+	assert.Nil(test, NewEmptyPersistentCollection().First(), "Empty collections should return nil")
+
+	positions := []int{}
+
+	for position := range collection.Iter() {
+		positions = append(positions, position)
+	}
+
+	assert.Equal(test, []int{0, 1, 2}, positions, "Wrong positions on Iter method")
+}
+
+func TestPersistentNonComparableElements(test *testing.T) {
+	collection, err := NewPersistentCollection([][]int{{1}, {2}})
+
+	assert.Nil(test, err, "Unexpected error with non comparable elements")
+	assert.True(test, collection.Contains([]int{2}), "Non comparable elements should be compared deeply")
+
+	_, err = collection.Add([]int{1})
+
+	assert.Equal(test, ErrDuplicatedElement, err, "Deeply equal elements should be duplicated")
+}
+
+func TestTransientCollection(test *testing.T) {
+	original, _ := NewPersistentCollection([]int{1})
+	transient := original.Transient()
+
+	assert.Nil(test, transient.AddRange([]int{2, 3, 4}), "Unexpected error adding to a transient collection")
+	assert.Nil(test, transient.Delete(1), "Unexpected error deleting from a transient collection")
+
+	persistent := transient.Persistent()
+	transient.Add(5)
+
+	assert.Equal(test, []Element{2, 3, 4}, persistent.Elements(), "Wrong elements of the persisted collection")
+	assert.Equal(test, 4, transient.Size(), "Transients should be usable after being persisted")
+	assert.Equal(test, []Element{1}, original.Elements(), "Transients shouldn't modify the original collection")
+
+	assert.Equal(test, &DuplicateError{Value: 2, Index: 1}, transient.AddRange([]int{6, 2}), "Duplicated elements in range should return an error")
+	assert.Equal(test, 4, transient.Size(), "Failed AddRange calls should leave the transient collection untouched")
+
+	err := transient.AddRange([]Element{7, 7, "8"}, JoinErrors())
+
+	assert.Equal(test, errors.Join(
+		&DuplicateError{Value: 7, Index: 1},
+		&TypeMismatchError{Expected: reflect.TypeOf(0), Actual: reflect.TypeOf(""), Index: 2},
+	), err, "JoinErrors option should return all the failures of transient AddRange")
+	assert.Equal(test, 4, transient.Size(), "Failed AddRange calls should leave the transient collection untouched")
+}
+
+func TestPersistentConversions(test *testing.T) {
+	collection := NewCollection([]int{3, 1, 2})
+	persistent := collection.Persistent()
+
+	assert.Equal(test, collection.Elements(), persistent.Elements(), "Wrong elements converting to a persistent collection")
+	assert.Equal(test, collection.Elements(), persistent.Collection().Elements(), "Wrong elements converting from a persistent collection")
+	assert.True(test, persistent.Collection().Contains(2), "Converted collections should be indexed")
+}
+
+func TestPersistentRandomOperations(test *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	current := NewEmptyPersistentCollection()
+	expected := []Element{}
+
+	for operation := 0; operation < 2000; operation++ {
+		element := random.Intn(100)
+		position := -1
+
+		for x, current := range expected {
+			if current == element {
+				position = x
+			}
+		}
+
+		if position >= 0 {
+			current, _ = current.Delete(element)
+			expected = append(expected[:position:position], expected[position+1:]...)
+		} else {
+			current, _ = current.Add(element)
+			expected = append(expected, element)
+		}
+
+		assert.Equal(test, len(expected), current.Size(), "Wrong size after random operations")
+	}
+
+	assert.Equal(test, expected, current.Elements(), "Wrong elements after random operations")
+
+	for position, element := range expected {
+		assert.Equal(test, element, current.ElementAt(position), "Wrong element at position after random operations")
+	}
+}
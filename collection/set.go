@@ -0,0 +1,165 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// The datatypes/collection package provides new structures and
+// behaviours to the iteration of non-sorted unique element and homogeneous
+// lists accepting primitives types and complex user structs as well.
+
+// This part of package contains the set algebra between collections
+
+package collection
+
+import "reflect"
+
+// Union returns a new collection with the elements contained in any of both collections
+// The elements of the instanced collection go first, followed by the ones
+// only contained in the parameter collection
+func (col *Collection) Union(collection *Collection) (*Collection, error) {
+	if !col.isCompatibleWith(collection) {
+		return nil, ErrInvalidElementType
+	}
+
+	elements := append([]Element{}, col.elements...)
+
+	for _, element := range collection.elements {
+		if !col.Contains(element) {
+			elements = append(elements, element)
+		}
+	}
+
+	return newDerivedCollection(elements), nil
+}
+
+// Intersection returns a new collection with the elements contained in both collections
+func (col *Collection) Intersection(collection *Collection) (*Collection, error) {
+	if !col.isCompatibleWith(collection) {
+		return nil, ErrInvalidElementType
+	}
+
+	var elements []Element
+
+	for _, element := range col.elements {
+		if collection.Contains(element) {
+			elements = append(elements, element)
+		}
+	}
+
+	return newDerivedCollection(elements), nil
+}
+
+// Difference returns a new collection with the elements contained in the
+// instanced collection which are not contained in the parameter collection
+func (col *Collection) Difference(collection *Collection) (*Collection, error) {
+	if !col.isCompatibleWith(collection) {
+		return nil, ErrInvalidElementType
+	}
+
+	var elements []Element
+
+	for _, element := range col.elements {
+		if !collection.Contains(element) {
+			elements = append(elements, element)
+		}
+	}
+
+	return newDerivedCollection(elements), nil
+}
+
+// SymmetricDifference returns a new collection with the elements contained
+// in only one of both collections
+func (col *Collection) SymmetricDifference(collection *Collection) (*Collection, error) {
+	if !col.isCompatibleWith(collection) {
+		return nil, ErrInvalidElementType
+	}
+
+	var elements []Element
+
+	for _, element := range col.elements {
+		if !collection.Contains(element) {
+			elements = append(elements, element)
+		}
+	}
+
+	for _, element := range collection.elements {
+		if !col.Contains(element) {
+			elements = append(elements, element)
+		}
+	}
+
+	return newDerivedCollection(elements), nil
+}
+
+// IsSubsetOf checks if all the elements of the instanced collection
+// are contained in the parameter collection
+func (col *Collection) IsSubsetOf(collection *Collection) (bool, error) {
+	if !col.isCompatibleWith(collection) {
+		return false, ErrInvalidElementType
+	}
+
+	if col.Size() > collection.Size() {
+		return false, nil
+	}
+
+	for _, element := range col.elements {
+		if !collection.Contains(element) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// IsSupersetOf checks if all the elements of the parameter collection
+// are contained in the instanced collection
+func (col *Collection) IsSupersetOf(collection *Collection) (bool, error) {
+	return collection.IsSubsetOf(col)
+}
+
+// IsDisjointWith checks if both collections have no elements in common
+func (col *Collection) IsDisjointWith(collection *Collection) (bool, error) {
+	if !col.isCompatibleWith(collection) {
+		return false, ErrInvalidElementType
+	}
+
+	for _, element := range col.elements {
+		if collection.Contains(element) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// Equals checks if both collections contain the same elements regardless of their order
+func (col *Collection) Equals(collection *Collection) (bool, error) {
+	if !col.isCompatibleWith(collection) {
+		return false, ErrInvalidElementType
+	}
+
+	if col.Size() != collection.Size() {
+		return false, nil
+	}
+
+	return col.IsSubsetOf(collection)
+}
+
+// isCompatibleWith checks if the elements of both collections have the same type
+// Empty collections are compatible with any other collection
+func (col *Collection) isCompatibleWith(collection *Collection) bool {
+	return col.IsEmpty() || collection.IsEmpty() || col.definition == collection.definition
+}
+
+// newDerivedCollection instances a new collection storing the specified elements
+// as they are, so they must be already unique and homogeneous
+func newDerivedCollection(elements []Element) *Collection {
+	collection := NewEmptyCollection()
+
+	if len(elements) > 0 {
+		collection.definition = reflect.TypeOf(elements[0])
+		collection.elements = elements
+		collection.lookup()
+	}
+
+	return collection
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// The datatypes/collection package provides new structures and
+// behaviours to the iteration of non-sorted unique element and homogeneous
+// lists accepting primitives types and complex user structs as well.
+
+// This part of package contains the tests for the set algebra
+
+package collection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnionMethod(test *testing.T) {
+	collection := NewCollection([]int{1, 2, 3})
+	otherCollection := NewCollection([]int{3, 4})
+
+	union, err := collection.Union(otherCollection)
+
+	assert.Nil(test, err, "Unexpected error on Union method")
+	assert.Equal(test, []Element{1, 2, 3, 4}, union.Elements(), "Wrong elements on Union method")
+	assert.Len(test, collection.elements, 3, "Union method shouldn't modify the instanced collection")
+
+	union, err = NewEmptyCollection().Union(otherCollection)
+
+	assert.Nil(test, err, "Unexpected error on Union method with an empty collection")
+	assert.Nil(test, union.Add(5), "Union of an empty collection should take the type definition of the other one")
+
+	_, err = collection.Union(NewCollection([]string{"1"}))
+
+	assert.Equal(test, ErrInvalidElementType, err, "Non-homogeneous collections should return an error on Union method")
+}
+
+func TestIntersectionMethod(test *testing.T) {
+	collection := NewCollection([]int{1, 2, 3})
+	otherCollection := NewCollection([]int{3, 2, 5})
+
+	intersection, err := collection.Intersection(otherCollection)
+
+	assert.Nil(test, err, "Unexpected error on Intersection method")
+	assert.Equal(test, []Element{2, 3}, intersection.Elements(), "Wrong elements on Intersection method")
+
+	_, err = collection.Intersection(NewCollection([]string{"1"}))
+
+	assert.Equal(test, ErrInvalidElementType, err, "Non-homogeneous collections should return an error on Intersection method")
+}
+
+func TestDifferenceMethod(test *testing.T) {
+	collection := NewCollection([]int{1, 2, 3})
+	otherCollection := NewCollection([]int{2, 5})
+
+	difference, err := collection.Difference(otherCollection)
+
+	assert.Nil(test, err, "Unexpected error on Difference method")
+	assert.Equal(test, []Element{1, 3}, difference.Elements(), "Wrong elements on Difference method")
+
+	symmetric, err := collection.SymmetricDifference(otherCollection)
+
+	assert.Nil(test, err, "Unexpected error on SymmetricDifference method")
+	assert.Equal(test, []Element{1, 3, 5}, symmetric.Elements(), "Wrong elements on SymmetricDifference method")
+
+	_, err = collection.SymmetricDifference(NewCollection([]string{"1"}))
+
+	assert.Equal(test, ErrInvalidElementType, err, "Non-homogeneous collections should return an error on SymmetricDifference method")
+}
+
+func TestSubsetMethods(test *testing.T) {
+	collection := NewCollection([]int{1, 2, 3})
+	subset := NewCollection([]int{3, 1})
+	disjoint := NewCollection([]int{4, 5})
+
+	isSubset, err := subset.IsSubsetOf(collection)
+
+	assert.Nil(test, err, "Unexpected error on IsSubsetOf method")
+	assert.True(test, isSubset, "IsSubsetOf return a false negative")
+
+	isSubset, _ = collection.IsSubsetOf(subset)
+
+	assert.False(test, isSubset, "IsSubsetOf return a false positive")
+
+	isSuperset, _ := collection.IsSupersetOf(subset)
+
+	assert.True(test, isSuperset, "IsSupersetOf return a false negative")
+
+	isDisjoint, _ := collection.IsDisjointWith(disjoint)
+
+	assert.True(test, isDisjoint, "IsDisjointWith return a false negative")
+
+	isDisjoint, _ = collection.IsDisjointWith(subset)
+
+	assert.False(test, isDisjoint, "IsDisjointWith return a false positive")
+
+	_, err = collection.IsSubsetOf(NewCollection([]string{"1"}))
+
+	assert.Equal(test, ErrInvalidElementType, err, "Non-homogeneous collections should return an error on IsSubsetOf method")
+}
+
+func TestEqualsMethod(test *testing.T) {
+	collection := NewCollection([]int{1, 2, 3})
+
+	equals, err := collection.Equals(NewCollection([]int{3, 1, 2}))
+
+	assert.Nil(test, err, "Unexpected error on Equals method")
+	assert.True(test, equals, "Equals method should ignore the order of the elements")
+
+	equals, _ = collection.Equals(NewCollection([]int{1, 2}))
+
+	assert.False(test, equals, "Equals return a false positive")
+
+	_, err = collection.Equals(NewCollection([]string{"1"}))
+
+	assert.Equal(test, ErrInvalidElementType, err, "Non-homogeneous collections should return an error on Equals method")
+}
@@ -0,0 +1,330 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// The datatypes/collection package provides new structures and
+// behaviours to the iteration of non-sorted unique element and homogeneous
+// lists accepting primitives types and complex user structs as well.
+
+// This part of package contains the sorted collection
+
+package collection
+
+import (
+	"math"
+	"reflect"
+	"sort"
+
+	"github.com/jaimelopez/datatypes/generic"
+)
+
+// SortedCollection represents a unique element and homogeneous list which keeps its
+// elements sorted, using a comparator function or the natural order of the elements
+// Two elements are considered the same one if the comparator returns zero for them
+type SortedCollection struct {
+	definition reflect.Type
+	comparator generic.Comparator
+	natural    bool
+	elements   []Element
+}
+
+// Add a single element in its sorted position
+// It follows the same rules than the Add method of Collection
+// If the collection uses the natural order, the elements must be integers, floats or strings
+func (col *SortedCollection) Add(element Element) error {
+	if col.IsEmpty() {
+		if col.natural && !generic.IsOrdered(reflect.TypeOf(element)) {
+			return ErrNonOrderedElement
+		}
+
+		col.definition = reflect.TypeOf(element)
+	} else if !col.isHomogeneousWith(element) {
+		return ErrInvalidElementType
+	}
+
+	position, found := col.search(element)
+
+	if found {
+		return ErrDuplicatedElement
+	}
+
+	col.elements = append(col.elements, nil)
+	copy(col.elements[position+1:], col.elements[position:])
+	col.elements[position] = element
+
+	return nil
+}
+
+// AddRange inserts a range (slice) inside the collection
+// If the parameter can't be converted to a iterable data type it's return an error.
+// The range is added all or nothing, so if any element can't be added
+// the collection is left untouched and a TypeMismatchError or a DuplicateError
+// is returned, or all of them joined if the JoinErrors option is specified
+func (col *SortedCollection) AddRange(elements ElementList, options ...RangeOption) error {
+	slice, err := generic.ToSlice(elements)
+
+	if err != nil || len(slice) == 0 {
+		return err
+	}
+
+	definition := col.definition
+
+	if col.IsEmpty() {
+		definition = reflect.TypeOf(slice[0])
+
+		if col.natural && !generic.IsOrdered(definition) {
+			return ErrNonOrderedElement
+		}
+	}
+
+	staged := &SortedCollection{definition: definition, comparator: col.comparator, natural: col.natural}
+
+	err = checkElements(slice, definition, func(position int, element Element) error {
+		if _, found := col.search(element); found || staged.Add(element) != nil {
+			return &DuplicateError{Value: element, Index: position}
+		}
+
+		return nil
+	}, options)
+
+	if err != nil {
+		return err
+	}
+
+	for _, element := range slice {
+		col.Add(element)
+	}
+
+	return nil
+}
+
+// Min returns the lowest element or nil if the collection is empty
+func (col *SortedCollection) Min() Element {
+	if col.IsEmpty() {
+		return nil
+	}
+
+	return col.elements[0]
+}
+
+// Max returns the greatest element or nil if the collection is empty
+func (col *SortedCollection) Max() Element {
+	if col.IsEmpty() {
+		return nil
+	}
+
+	return col.elements[len(col.elements)-1]
+}
+
+// ElementAt returns the element in the specified sorted position
+func (col *SortedCollection) ElementAt(position int) Element {
+	return col.elements[position]
+}
+
+// Elements returns the stored collection elements sorted
+func (col *SortedCollection) Elements() []Element {
+	return col.elements
+}
+
+// Extract the lowest element and return it
+// If the collection is empty it returns nil
+// Keep in mind that this method will modify the collection elements subtracting that element
+func (col *SortedCollection) Extract() Element {
+	element := col.Min()
+
+	if element != nil {
+		col.elements = col.elements[1:]
+	}
+
+	return element
+}
+
+// Delete removes an specified already stored element
+// If it's not found the method will return an error
+func (col *SortedCollection) Delete(element Element) error {
+	if !col.isHomogeneousWith(element) {
+		return ErrInvalidElementType
+	}
+
+	position, found := col.search(element)
+
+	if !found {
+		return ErrElementNotFound
+	}
+
+	col.elements = append(col.elements[:position], col.elements[position+1:]...)
+
+	return nil
+}
+
+// Contains checks if the specified element is already existing in the collection
+// using a binary search
+func (col *SortedCollection) Contains(element Element) bool {
+	return col.IndexOf(element) >= 0
+}
+
+// IndexOf returns the sorted position of the specified element
+// or -1 if it's not contained in the collection
+func (col *SortedCollection) IndexOf(element Element) int {
+	if col.IsEmpty() || !col.isHomogeneousWith(element) {
+		return -1
+	}
+
+	position, found := col.search(element)
+
+	if !found {
+		return -1
+	}
+
+	return position
+}
+
+// Range returns the sorted elements between the specified ones, both included
+func (col *SortedCollection) Range(from Element, to Element) []Element {
+	if col.IsEmpty() || !col.isHomogeneousWith(from) || !col.isHomogeneousWith(to) {
+		return []Element{}
+	}
+
+	start, _ := col.search(from)
+	end := sort.Search(len(col.elements), func(position int) bool {
+		return col.comparator(col.elements[position], to) > 0
+	})
+
+	if start >= end {
+		return []Element{}
+	}
+
+	return append([]Element{}, col.elements[start:end]...)
+}
+
+// Nearest returns the stored element closest to the specified one
+// If the element is not stored, numbers with natural order are compared by their
+// difference while any other element resolves to its lower neighbour when it exists
+// If the collection is empty it returns nil
+func (col *SortedCollection) Nearest(element Element) Element {
+	if col.IsEmpty() || !col.isHomogeneousWith(element) {
+		return nil
+	}
+
+	position, found := col.search(element)
+
+	if found || position == 0 {
+		return col.elements[position]
+	}
+
+	lower := col.elements[position-1]
+
+	if position == len(col.elements) {
+		return lower
+	}
+
+	higher := col.elements[position]
+
+	if col.natural && distance(element, higher) < distance(element, lower) {
+		return higher
+	}
+
+	return lower
+}
+
+// Filter returns the sorted elements filtering them with a function
+// If the functions return true the element will be filtered
+func (col *SortedCollection) Filter(f func(Element) bool) []Element {
+	var results []Element
+
+	for _, elem := range col.elements {
+		if f(elem) {
+			results = append(results, elem)
+		}
+	}
+
+	return results
+}
+
+// Size returns the number of elements inside the collection
+func (col *SortedCollection) Size() int {
+	return len(col.elements)
+}
+
+// IsEmpty checks if the collection is empty or not
+func (col *SortedCollection) IsEmpty() bool {
+	return col.Size() == 0
+}
+
+// Collection returns the sorted elements as a plain collection
+func (col *SortedCollection) Collection() *Collection {
+	return newDerivedCollection(append([]Element(nil), col.elements...))
+}
+
+func (col *SortedCollection) isHomogeneousWith(element Element) bool {
+	return col.definition == reflect.TypeOf(element)
+}
+
+// search returns the position of the specified element using a binary search
+// or the position where it should be inserted if it's not found
+func (col *SortedCollection) search(element Element) (int, bool) {
+	position := sort.Search(len(col.elements), func(position int) bool {
+		return col.comparator(col.elements[position], element) >= 0
+	})
+
+	found := position < len(col.elements) && col.comparator(col.elements[position], element) == 0
+
+	return position, found
+}
+
+// distance returns the absolute difference between two numbers of the same type
+// Strings have no distance so they always return zero
+func distance(first Element, second Element) float64 {
+	firstValue := reflect.ValueOf(first)
+	secondValue := reflect.ValueOf(second)
+
+	switch firstValue.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return math.Abs(float64(firstValue.Int()) - float64(secondValue.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return math.Abs(float64(firstValue.Uint()) - float64(secondValue.Uint()))
+	case reflect.Float32, reflect.Float64:
+		return math.Abs(firstValue.Float() - secondValue.Float())
+	}
+
+	return 0
+}
+
+// Sort sorts the elements of the collection in place using the specified less function
+// Elements considered equal keep their relative order
+// The reordering is notified as a single ResetEvent
+func (col *Collection) Sort(less func(Element, Element) bool) {
+	sort.SliceStable(col.elements, func(first int, second int) bool {
+		return less(col.elements[first], col.elements[second])
+	})
+
+	col.notify(Event{Type: ResetEvent})
+}
+
+// NewEmptySortedCollection instances a new empty collection sorted by the natural order of its elements
+func NewEmptySortedCollection() *SortedCollection {
+	return &SortedCollection{comparator: generic.Compare, natural: true}
+}
+
+// NewEmptySortedCollectionFunc instances a new empty collection sorted by the specified comparator
+func NewEmptySortedCollectionFunc(comparator generic.Comparator) *SortedCollection {
+	return &SortedCollection{comparator: comparator}
+}
+
+// NewSortedCollection allows to instance a new SortedCollection with a group of elements
+// sorted by their natural order
+func NewSortedCollection(elements ElementList) (*SortedCollection, error) {
+	collection := NewEmptySortedCollection()
+	err := collection.AddRange(elements)
+
+	return collection, err
+}
+
+// NewSortedCollectionFunc allows to instance a new SortedCollection with a group of elements
+// sorted by the specified comparator
+func NewSortedCollectionFunc(comparator generic.Comparator, elements ElementList) (*SortedCollection, error) {
+	collection := NewEmptySortedCollectionFunc(comparator)
+	err := collection.AddRange(elements)
+
+	return collection, err
+}
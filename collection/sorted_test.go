@@ -0,0 +1,108 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// The datatypes/collection package provides new structures and
+// behaviours to the iteration of non-sorted unique element and homogeneous
+// lists accepting primitives types and complex user structs as well.
+
+// This part of package contains the tests for the sorted collection
+
+package collection
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortedAddMethod(test *testing.T) {
+	collection, err := NewSortedCollection([]int{30, 10, 20})
+
+	assert.Nil(test, err, "Unexpected error instancing a sorted collection")
+	assert.Equal(test, []Element{10, 20, 30}, collection.Elements(), "Elements should be kept sorted")
+	assert.Equal(test, ErrDuplicatedElement, collection.Add(20), "Duplicated elements should return an error on sorted Add method")
+	assert.Equal(test, ErrInvalidElementType, collection.Add("25"), "Non-homogeneous elements should return an error on sorted Add method")
+	assert.Equal(test, ErrNonOrderedElement, NewEmptySortedCollection().Add(struct{}{}), "Non-ordered elements should return an error without comparator")
+}
+
+func TestSortedAddRangeMethod(test *testing.T) {
+	collection, _ := NewSortedCollection([]int{30, 10})
+
+	assert.Nil(test, collection.AddRange([]int{20, 40}), "Unexpected error adding a sorted range")
+	assert.Equal(test, &DuplicateError{Value: 20, Index: 1}, collection.AddRange([]int{50, 20}), "Duplicated elements in range should return an error")
+	assert.Equal(test, []Element{10, 20, 30, 40}, collection.Elements(), "Failed AddRange calls should leave the sorted collection untouched")
+
+	err := collection.AddRange([]Element{60, "70", 60}, JoinErrors())
+
+	assert.Equal(test, errors.Join(
+		&TypeMismatchError{Expected: reflect.TypeOf(0), Actual: reflect.TypeOf(""), Index: 1},
+		&DuplicateError{Value: 60, Index: 2},
+	), err, "JoinErrors option should return all the failures of sorted AddRange")
+	assert.Equal(test, 4, collection.Size(), "Failed AddRange calls should leave the sorted collection untouched")
+
+	insensitive := NewEmptySortedCollectionFunc(func(first interface{}, second interface{}) int {
+		return strings.Compare(strings.ToLower(first.(string)), strings.ToLower(second.(string)))
+	})
+
+	assert.Equal(test, &DuplicateError{Value: "A", Index: 1}, insensitive.AddRange([]string{"a", "A"}), "Elements equal for the comparator in range should be duplicated")
+	assert.True(test, insensitive.IsEmpty(), "Failed AddRange calls should leave the sorted collection untouched")
+	assert.Equal(test, ErrNonOrderedElement, NewEmptySortedCollection().AddRange([]struct{}{{}}), "Non-ordered ranges should return an error without comparator")
+}
+
+func TestSortedSearchMethods(test *testing.T) {
+	collection, _ := NewSortedCollection([]int{50, 10, 40, 20, 30})
+
+	assert.True(test, collection.Contains(40), "Contains return a false negative on sorted collection")
+	assert.False(test, collection.Contains(45), "Contains return a false positive on sorted collection")
+	assert.False(test, collection.Contains("40"), "Contains return a false positive with non-homogeneous elements")
+	assert.Equal(test, 3, collection.IndexOf(40), "Wrong position on IndexOf method")
+	assert.Equal(test, -1, collection.IndexOf(45), "Not found elements should return -1 on IndexOf method")
+	assert.Equal(test, 10, collection.Min(), "Wrong element on Min method")
+	assert.Equal(test, 50, collection.Max(), "Wrong element on Max method")
+	assert.Equal(test, []Element{20, 30, 40}, collection.Range(15, 40), "Wrong elements on Range method")
+	assert.Empty(test, collection.Range(41, 49), "Range without elements should return no elements")
+}
+
+func TestSortedNearestMethod(test *testing.T) {
+	collection, _ := NewSortedCollection([]int{10, 20, 40})
+
+	assert.Equal(test, 20, collection.Nearest(20), "Nearest should return the equal element")
+	assert.Equal(test, 40, collection.Nearest(35), "Nearest should return the closest number")
+	assert.Equal(test, 20, collection.Nearest(24), "Nearest should return the closest number")
+	assert.Equal(test, 10, collection.Nearest(-5), "Nearest should return the lowest element for lower numbers")
+	assert.Equal(test, 40, collection.Nearest(100), "Nearest should return the greatest element for greater numbers")
+	assert.Nil(test, NewEmptySortedCollection().Nearest(1), "Nearest should return nil on empty collections")
+}
+
+func TestSortedExtractAndDeleteMethods(test *testing.T) {
+	collection, _ := NewSortedCollection([]string{"c", "a", "b"})
+
+	assert.Equal(test, "a", collection.Extract(), "Extract should return the lowest element")
+	assert.Nil(test, collection.Delete("c"), "Unexpected error on sorted Delete method")
+	assert.Equal(test, ErrElementNotFound, collection.Delete("c"), "Not found elements should return an error on sorted Delete method")
+	assert.Equal(test, []Element{"b"}, collection.Collection().Elements(), "Wrong remained elements after extraction and deletion")
+}
+
+func TestSortedComparator(test *testing.T) {
+	collection, _ := NewSortedCollectionFunc(func(first interface{}, second interface{}) int {
+		return strings.Compare(strings.ToLower(first.(string)), strings.ToLower(second.(string)))
+	}, []string{"b", "A", "c"})
+
+	assert.Equal(test, []Element{"A", "b", "c"}, collection.Elements(), "Elements should be sorted by the comparator")
+	assert.Equal(test, ErrDuplicatedElement, collection.Add("a"), "Elements equal for the comparator should be considered duplicated")
+}
+
+func TestSortMethod(test *testing.T) {
+	collection := NewCollection([]int{3, 1, 2})
+
+	collection.Sort(func(first Element, second Element) bool {
+		return first.(int) < second.(int)
+	})
+
+	assert.Equal(test, []Element{1, 2, 3}, collection.Elements(), "Sort method should sort the elements in place")
+	assert.True(test, collection.Contains(3), "Sorted collection should keep its index")
+}
@@ -0,0 +1,230 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// The datatypes/collection package provides new structures and
+// behaviours to the iteration of non-sorted unique element and homogeneous
+// lists accepting primitives types and complex user structs as well.
+
+// This part of package contains the database/sql integration of collections
+
+package collection
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/jaimelopez/datatypes/internal/codec"
+)
+
+// postgresEscaper escapes the special characters of quoted elements in Postgres array literals
+var postgresEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// PostgresArray wraps a collection to store it as a Postgres array literal instead of a JSON array
+type PostgresArray struct {
+	Collection *Collection
+}
+
+// Value implements driver.Valuer storing the collection as a JSON array
+// A nil collection is stored as NULL
+func (col *Collection) Value() (driver.Value, error) {
+	if col == nil {
+		return nil, nil
+	}
+
+	return col.MarshalJSON()
+}
+
+// Scan implements sql.Scanner replacing the elements of the collection with the
+// stored ones, which can be a JSON array or a Postgres array literal
+// NULL values are scanned as an empty collection. Elements of array literals
+// are scanned as strings unless a concrete type has been registered with DecodeAs
+func (col *Collection) Scan(src interface{}) error {
+	var data []byte
+
+	switch value := src.(type) {
+	case nil:
+		return col.replace(nil)
+	case []byte:
+		data = value
+	case string:
+		data = []byte(value)
+	default:
+		return ErrInvalidScanSource
+	}
+
+	data = bytes.TrimSpace(data)
+
+	if len(data) > 0 && data[0] == '{' {
+		elements, err := parsePostgresArray(string(data), col.decoding)
+
+		if err != nil {
+			return err
+		}
+
+		return col.replace(elements)
+	}
+
+	err := col.UnmarshalJSON(data)
+
+	var decodeErr *DecodeError
+
+	if err == nil || errors.As(err, &decodeErr) && isPackageError(decodeErr.Err) {
+		return err
+	}
+
+	if decodeErr != nil {
+		return &DecodeError{Index: decodeErr.Index, Err: ErrInvalidElementType}
+	}
+
+	return ErrInvalidScanSource
+}
+
+// AsPostgresArray returns the collection wrapped to be stored as a Postgres array literal
+func (col *Collection) AsPostgresArray() PostgresArray {
+	return PostgresArray{Collection: col}
+}
+
+// Value implements driver.Valuer storing the collection as a Postgres array literal
+// Only booleans, numbers and strings are supported, otherwise it returns ErrUnsupportedType
+// A nil collection is stored as NULL
+func (array PostgresArray) Value() (driver.Value, error) {
+	if array.Collection == nil {
+		return nil, nil
+	}
+
+	if !codec.IsSupported(array.Collection.definition) {
+		return nil, ErrUnsupportedType
+	}
+
+	literal := make([]string, 0, array.Collection.Size())
+
+	for _, element := range array.Collection.elements {
+		if reflect.TypeOf(element).Kind() != reflect.String {
+			literal = append(literal, fmt.Sprint(element))
+
+			continue
+		}
+
+		text := reflect.ValueOf(element).String()
+		literal = append(literal, `"`+postgresEscaper.Replace(text)+`"`)
+	}
+
+	return "{" + strings.Join(literal, ",") + "}", nil
+}
+
+// Scan implements sql.Scanner replacing the elements of the wrapped collection
+func (array PostgresArray) Scan(src interface{}) error {
+	return array.Collection.Scan(src)
+}
+
+// parsePostgresArray parses a one-dimensional Postgres array literal
+func parsePostgresArray(literal string, definition reflect.Type) ([]Element, error) {
+	if len(literal) < 2 || literal[0] != '{' || literal[len(literal)-1] != '}' {
+		return nil, ErrInvalidScanSource
+	}
+
+	body := literal[1 : len(literal)-1]
+	elements := []Element{}
+
+	for position := 0; position < len(body); {
+		var text strings.Builder
+
+		quoted := body[position] == '"'
+
+		if quoted {
+			position++
+
+			for ; position < len(body) && body[position] != '"'; position++ {
+				if body[position] == '\\' {
+					position++
+				}
+
+				if position < len(body) {
+					text.WriteByte(body[position])
+				}
+			}
+
+			if position >= len(body) {
+				return nil, ErrInvalidScanSource
+			}
+
+			position++
+		} else {
+			for ; position < len(body) && body[position] != ','; position++ {
+				if body[position] == '{' || body[position] == '"' {
+					return nil, ErrInvalidScanSource
+				}
+
+				if body[position] == '\\' && position+1 < len(body) {
+					position++
+				}
+
+				text.WriteByte(body[position])
+			}
+		}
+
+		if !quoted && text.Len() == 0 {
+			return nil, ErrInvalidScanSource
+		}
+
+		if !quoted && strings.EqualFold(text.String(), "NULL") {
+			return nil, ErrInvalidElementType
+		}
+
+		element, err := parsePostgresElement(text.String(), definition)
+
+		if err != nil {
+			return nil, err
+		}
+
+		elements = append(elements, element)
+
+		if position < len(body) {
+			if body[position] != ',' || position == len(body)-1 {
+				return nil, ErrInvalidScanSource
+			}
+
+			position++
+		}
+	}
+
+	return elements, nil
+}
+
+func isPackageError(err error) bool {
+	return errors.Is(err, ErrInvalidElementType) || errors.Is(err, ErrDuplicatedElement)
+}
+
+func parsePostgresElement(text string, definition reflect.Type) (Element, error) {
+	if definition == nil {
+		return text, nil
+	}
+
+	element := reflect.New(definition)
+
+	switch definition.Kind() {
+	case reflect.String:
+		element.Elem().SetString(text)
+	case reflect.Bool:
+		switch strings.ToLower(text) {
+		case "t", "true":
+			element.Elem().SetBool(true)
+		case "f", "false":
+			element.Elem().SetBool(false)
+		default:
+			return nil, ErrInvalidElementType
+		}
+	default:
+		if err := json.Unmarshal([]byte(text), element.Interface()); err != nil {
+			return nil, ErrInvalidElementType
+		}
+	}
+
+	return element.Elem().Interface(), nil
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// The datatypes/collection package provides new structures and
+// behaviours to the iteration of non-sorted unique element and homogeneous
+// lists accepting primitives types and complex user structs as well.
+
+// This part of package contains the tests for the database/sql integration
+
+package collection
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jaimelopez/datatypes/internal/sqltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDatabaseRoundTrip(test *testing.T) {
+	db := sqltest.Open()
+	defer db.Close()
+
+	tags := NewCollection([]string{"go", "sql"})
+
+	_, err := db.Exec("INSERT INTO tags VALUES (?, ?)", 1, tags)
+
+	assert.Nil(test, err, "Unexpected error storing a collection")
+
+	scanned := NewEmptyCollection()
+
+	assert.Nil(test, db.QueryRow("SELECT tags FROM tags WHERE id = ?", 1).Scan(scanned), "Unexpected error scanning a collection")
+	assert.Equal(test, tags.Elements(), scanned.Elements(), "Wrong round trip through the database")
+
+	_, err = db.Exec("INSERT INTO tags VALUES (?, ?)", 2, tags.AsPostgresArray())
+
+	assert.Nil(test, err, "Unexpected error storing a Postgres array")
+
+	var stored string
+
+	db.QueryRow("SELECT tags FROM tags WHERE id = ?", 2).Scan(&stored)
+
+	assert.Equal(test, `{"go","sql"}`, stored, "Wrong stored Postgres array literal")
+
+	scanned = NewEmptyCollection()
+
+	assert.Nil(test, db.QueryRow("SELECT tags FROM tags WHERE id = ?", 2).Scan(scanned.AsPostgresArray()), "Unexpected error scanning a Postgres array")
+	assert.Equal(test, tags.Elements(), scanned.Elements(), "Wrong round trip of a Postgres array")
+}
+
+func TestScanMethod(test *testing.T) {
+	collection := NewCollection([]int{1})
+
+	assert.Nil(test, collection.Scan(nil), "Unexpected error scanning a NULL value")
+	assert.True(test, collection.IsEmpty(), "NULL values should be scanned as empty collections")
+
+	assert.Nil(test, collection.Scan(`{"a b","c\"d",e\\f}`), "Unexpected error scanning an array literal")
+	assert.Equal(test, []Element{"a b", `c"d`, `e\f`}, collection.Elements(), "Wrong elements scanning an array literal")
+
+	numbers := NewEmptyCollection().DecodeAs(0)
+
+	assert.Nil(test, numbers.Scan([]byte(`{1,2,3}`)), "Unexpected error scanning a typed array literal")
+	assert.Equal(test, []Element{1, 2, 3}, numbers.Elements(), "Wrong elements scanning a typed array literal")
+	assert.True(test, errors.Is(numbers.Scan(`{1,1}`), ErrDuplicatedElement), "Duplicated elements should return an error")
+	assert.True(test, errors.Is(numbers.Scan(`{1,a}`), ErrInvalidElementType), "Wrong typed elements should return an error")
+	assert.True(test, errors.Is(numbers.Scan(`[1,"a"]`), ErrInvalidElementType), "Wrong typed JSON elements should return an error")
+	assert.True(test, errors.Is(numbers.Scan(`{1,NULL}`), ErrInvalidElementType), "NULL elements should return an error")
+
+	assert.Equal(test, ErrInvalidScanSource, numbers.Scan(`{1,2`), "Malformed array literals should return an error")
+	assert.Equal(test, ErrInvalidScanSource, numbers.Scan(`[1,2`), "Malformed JSON should return an error")
+	assert.Equal(test, ErrInvalidScanSource, numbers.Scan(42), "Unsupported sources should return an error")
+	assert.Equal(test, []Element{1, 2, 3}, numbers.Elements(), "Failed scans should leave the collection untouched")
+}
+
+func TestNilCollectionValue(test *testing.T) {
+	var collection *Collection
+
+	value, err := collection.Value()
+
+	assert.Nil(test, err, "Unexpected error on the Value method of a nil collection")
+	assert.Nil(test, value, "Nil collections should be stored as NULL")
+
+	value, err = collection.AsPostgresArray().Value()
+
+	assert.Nil(test, err, "Unexpected error on the Postgres array Value method of a nil collection")
+	assert.Nil(test, value, "Nil collections should be stored as NULL Postgres arrays")
+}
+
+func TestPostgresArrayValue(test *testing.T) {
+	value, err := NewCollection([]string{`a"b`, `c\d`}).AsPostgresArray().Value()
+
+	assert.Nil(test, err, "Unexpected error on Postgres array Value method")
+	assert.Equal(test, `{"a\"b","c\\d"}`, value, "Wrong escaped Postgres array literal")
+
+	value, _ = NewCollection([]float64{1.5, 2}).AsPostgresArray().Value()
+
+	assert.Equal(test, `{1.5,2}`, value, "Wrong numeric Postgres array literal")
+
+	_, err = NewCollection([]struct{ A int }{{1}}).AsPostgresArray().Value()
+
+	assert.Equal(test, ErrUnsupportedType, err, "Non primitive elements can't be stored as Postgres arrays")
+}
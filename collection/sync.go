@@ -0,0 +1,179 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// The datatypes/collection package provides new structures and
+// behaviours to the iteration of non-sorted unique element and homogeneous
+// lists accepting primitives types and complex user structs as well.
+
+// This part of package contains the thread-safe collection
+
+package collection
+
+import "sync"
+
+// SyncCollection represents a collection safe for concurrent use by multiple goroutines
+// Read operations are allowed to run in parallel while the mutating ones are exclusive
+type SyncCollection struct {
+	mutex      sync.RWMutex
+	collection *Collection
+}
+
+// Add a single element to the collection
+// It follows the same rules than the Add method of Collection
+func (col *SyncCollection) Add(element Element) error {
+	col.mutex.Lock()
+	defer col.mutex.Unlock()
+
+	return col.collection.Add(element)
+}
+
+// AddRange inserts a range (slice) inside the collection
+// If the parameter can't be converted to a iterable data type it's return an error
+func (col *SyncCollection) AddRange(elements ElementList, options ...RangeOption) error {
+	col.mutex.Lock()
+	defer col.mutex.Unlock()
+
+	return col.collection.AddRange(elements, options...)
+}
+
+// AddIfAbsent adds the element only if it's not contained yet in the collection
+// checking and adding it atomically
+// It returns true if the element has been added
+func (col *SyncCollection) AddIfAbsent(element Element) (bool, error) {
+	col.mutex.Lock()
+	defer col.mutex.Unlock()
+
+	if col.collection.Contains(element) {
+		return false, nil
+	}
+
+	if err := col.collection.Add(element); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Elements returns a copy of the stored collection elements
+func (col *SyncCollection) Elements() []Element {
+	col.mutex.RLock()
+	defer col.mutex.RUnlock()
+
+	return append([]Element(nil), col.collection.elements...)
+}
+
+// Extract the first element and return it
+// Unlike the Collection one, it returns nil if the collection is empty
+// so it's not needed to check if there are elements before calling it
+func (col *SyncCollection) Extract() Element {
+	col.mutex.Lock()
+	defer col.mutex.Unlock()
+
+	if col.collection.IsEmpty() {
+		return nil
+	}
+
+	return col.collection.Extract()
+}
+
+// ExtractIf removes atomically all the elements which satisfy the specified function
+// and returns them
+func (col *SyncCollection) ExtractIf(f func(Element) bool) []Element {
+	col.mutex.Lock()
+	defer col.mutex.Unlock()
+
+	extracted := col.collection.Filter(f)
+
+	for _, element := range extracted {
+		col.collection.Delete(element)
+	}
+
+	return extracted
+}
+
+// Delete removes an specified already stored element
+// If it's not found the method will return an error
+func (col *SyncCollection) Delete(element Element) error {
+	col.mutex.Lock()
+	defer col.mutex.Unlock()
+
+	return col.collection.Delete(element)
+}
+
+// DeleteRange removes all the found elements contained in the specified range (slice)
+// If the parameter can't be converted to a iterable data type it's return an error
+func (col *SyncCollection) DeleteRange(elements ElementList, options ...RangeOption) error {
+	col.mutex.Lock()
+	defer col.mutex.Unlock()
+
+	return col.collection.DeleteRange(elements, options...)
+}
+
+// Contains checks if the specified element is already existing in the collection
+func (col *SyncCollection) Contains(element Element) bool {
+	col.mutex.RLock()
+	defer col.mutex.RUnlock()
+
+	return col.collection.Contains(element)
+}
+
+// ContainsAny checks if any of the parameter elements there are already contained in the collection
+func (col *SyncCollection) ContainsAny(elements ElementList) bool {
+	col.mutex.RLock()
+	defer col.mutex.RUnlock()
+
+	return col.collection.ContainsAny(elements)
+}
+
+// Filter returns a element colecction filtering the elements with a function
+// If the functions return true the element will be filtered
+// The function must not call any other method of the collection
+func (col *SyncCollection) Filter(f func(Element) bool) []Element {
+	col.mutex.RLock()
+	defer col.mutex.RUnlock()
+
+	return col.collection.Filter(f)
+}
+
+// Size returns the number of elements inside the collection
+func (col *SyncCollection) Size() int {
+	col.mutex.RLock()
+	defer col.mutex.RUnlock()
+
+	return col.collection.Size()
+}
+
+// IsEmpty checks if the collection is empty or not
+func (col *SyncCollection) IsEmpty() bool {
+	return col.Size() == 0
+}
+
+// Collection returns a snapshot of the current elements as a non thread-safe collection
+func (col *SyncCollection) Collection() *Collection {
+	col.mutex.RLock()
+	defer col.mutex.RUnlock()
+
+	return col.collection.clone()
+}
+
+// Synchronized returns a thread-safe collection wrapping the instanced one
+// The instanced collection must not be used directly anymore after calling it
+func (col *Collection) Synchronized() *SyncCollection {
+	// The index is built now since the read operations, which run in parallel,
+	// would build it lazily otherwise
+	col.lookup()
+
+	return &SyncCollection{collection: col}
+}
+
+// NewEmptySyncCollection instances a new empty thread-safe collection
+func NewEmptySyncCollection() *SyncCollection {
+	return NewEmptyCollection().Synchronized()
+}
+
+// NewSyncCollection allows to instance a new thread-safe collection with a group of elements
+// It accepts an enumerable
+func NewSyncCollection(elements ElementList) *SyncCollection {
+	return NewCollection(elements).Synchronized()
+}
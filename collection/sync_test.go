@@ -0,0 +1,146 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// The datatypes/collection package provides new structures and
+// behaviours to the iteration of non-sorted unique element and homogeneous
+// lists accepting primitives types and complex user structs as well.
+
+// This part of package contains the tests for the thread-safe collection
+
+package collection
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const goroutines = 8
+
+func TestSyncConcurrentAdd(test *testing.T) {
+	collection := NewEmptySyncCollection()
+
+	var group sync.WaitGroup
+
+	for worker := 0; worker < goroutines; worker++ {
+		group.Add(1)
+
+		go func() {
+			defer group.Done()
+
+			for element := 0; element < 100; element++ {
+				collection.Add(element)
+				collection.Contains(element)
+				collection.Filter(func(elem Element) bool { return elem.(int)%2 == 0 })
+			}
+		}()
+	}
+
+	group.Wait()
+
+	assert.Equal(test, 100, collection.Size(), "Concurrent additions should keep the elements unique")
+}
+
+func TestSyncConcurrentExtract(test *testing.T) {
+	elements := make([]int, 1000)
+
+	for x := range elements {
+		elements[x] = x
+	}
+
+	collection := NewSyncCollection(elements)
+
+	var group sync.WaitGroup
+	var extracted int64
+
+	for worker := 0; worker < goroutines; worker++ {
+		group.Add(1)
+
+		go func() {
+			defer group.Done()
+
+			for collection.Extract() != nil {
+				atomic.AddInt64(&extracted, 1)
+			}
+		}()
+	}
+
+	group.Wait()
+
+	assert.EqualValues(test, len(elements), extracted, "Every element should be extracted exactly once")
+	assert.True(test, collection.IsEmpty(), "Collection should be empty after extracting all the elements")
+	assert.Nil(test, collection.Extract(), "Extracting from an empty collection should return nil")
+}
+
+func TestSyncAddIfAbsentMethod(test *testing.T) {
+	collection := NewEmptySyncCollection()
+
+	var group sync.WaitGroup
+	var added int64
+
+	for worker := 0; worker < goroutines; worker++ {
+		group.Add(1)
+
+		go func() {
+			defer group.Done()
+
+			if ok, _ := collection.AddIfAbsent("element"); ok {
+				atomic.AddInt64(&added, 1)
+			}
+		}()
+	}
+
+	group.Wait()
+
+	assert.EqualValues(test, 1, added, "Only one goroutine should add the absent element")
+
+	_, err := collection.AddIfAbsent(1)
+
+	assert.Equal(test, ErrInvalidElementType, err, "Non-homogeneous elements should return an error on AddIfAbsent method")
+}
+
+func TestSyncExtractIfMethod(test *testing.T) {
+	collection := NewSyncCollection([]int{1, 2, 3, 4})
+
+	extracted := collection.ExtractIf(func(elem Element) bool { return elem.(int)%2 == 0 })
+
+	assert.Equal(test, []Element{2, 4}, extracted, "Wrong extracted elements on ExtractIf method")
+	assert.Equal(test, []Element{1, 3}, collection.Elements(), "Wrong remained elements on ExtractIf method")
+}
+
+func TestSynchronizedMethod(test *testing.T) {
+	collection := NewCollection([]int{1, 2})
+	synchronized := collection.Synchronized()
+
+	snapshot := synchronized.Collection()
+	synchronized.Add(3)
+
+	assert.Equal(test, 3, synchronized.Size(), "Synchronized collection should wrap the original one")
+	assert.Equal(test, 2, snapshot.Size(), "Snapshots shouldn't share the storage with the synchronized collection")
+}
+
+func TestSyncConcurrentReads(test *testing.T) {
+	first := NewCollection([]int{1, 2})
+	second := NewCollection([]int{2, 3})
+	union, _ := first.Union(second)
+
+	for _, collection := range []*SyncCollection{union.Synchronized(), first.Where(func(Element) bool { return true }).Synchronized()} {
+		var group sync.WaitGroup
+
+		for x := 0; x < goroutines; x++ {
+			group.Add(1)
+
+			go func() {
+				defer group.Done()
+
+				assert.True(test, collection.Contains(2), "Concurrent readers should find the stored elements")
+				assert.True(test, collection.ContainsAny([]int{5, 2}), "Concurrent readers should find any of the stored elements")
+			}()
+		}
+
+		group.Wait()
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// The datatypes/collection package provides new structures and
+// behaviours to the iteration of non-sorted unique element and homogeneous
+// lists accepting primitives types and complex user structs as well.
+
+// This part of package contains the transactions over collections
+
+package collection
+
+// Transaction represents a group of operations over a collection which
+// are applied atomically, so either all of them are applied or none
+type Transaction struct {
+	collection *Collection
+	operations []func(*Collection) error
+	closed     bool
+}
+
+// Add stages the addition of an element
+func (tx *Transaction) Add(element Element) error {
+	return tx.stage(func(col *Collection) error {
+		return col.Add(element)
+	})
+}
+
+// Set stages the replacement of the element in the specified position
+// The position refers to the collection with the previous operations applied
+func (tx *Transaction) Set(position int, element Element) error {
+	return tx.stage(func(col *Collection) error {
+		return col.SetAt(position, element)
+	})
+}
+
+// Delete stages the deletion of an element
+func (tx *Transaction) Delete(element Element) error {
+	return tx.stage(func(col *Collection) error {
+		return col.Delete(element)
+	})
+}
+
+// Commit applies all the staged operations in order to the collection
+// If any of them fails, it returns its error and the collection is left untouched.
+// The changes are notified all together once they're applied.
+// Either way the transaction is closed and can't be used anymore
+func (tx *Transaction) Commit() error {
+	if tx.closed {
+		return ErrTransactionClosed
+	}
+
+	tx.closed = true
+
+	var events []Event
+
+	staged := tx.collection.clone()
+	staged.Subscribe(func(batch []Event) {
+		events = append(events, batch...)
+	})
+
+	for _, operation := range tx.operations {
+		if err := operation(staged); err != nil {
+			return err
+		}
+	}
+
+	tx.collection.definition = staged.definition
+	tx.collection.elements = staged.elements
+	tx.collection.index = staged.index
+
+	defer tx.collection.batch()()
+
+	for _, event := range events {
+		tx.collection.notify(event)
+	}
+
+	return nil
+}
+
+// Rollback discards all the staged operations and closes the transaction
+func (tx *Transaction) Rollback() error {
+	if tx.closed {
+		return ErrTransactionClosed
+	}
+
+	tx.closed = true
+	tx.operations = nil
+
+	return nil
+}
+
+func (tx *Transaction) stage(operation func(*Collection) error) error {
+	if tx.closed {
+		return ErrTransactionClosed
+	}
+
+	tx.operations = append(tx.operations, operation)
+
+	return nil
+}
+
+// Begin starts a new transaction over the collection
+// The operations are only validated when the transaction is committed
+// against the elements the collection has at that moment
+func (col *Collection) Begin() *Transaction {
+	return &Transaction{collection: col}
+}
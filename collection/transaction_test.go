@@ -0,0 +1,90 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// The datatypes/collection package provides new structures and
+// behaviours to the iteration of non-sorted unique element and homogeneous
+// lists accepting primitives types and complex user structs as well.
+
+// This part of package contains the tests for the transactions
+
+package collection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransactionCommit(test *testing.T) {
+	collection := NewCollection([]string{"a", "b"})
+	received := [][]Event{}
+
+	collection.Subscribe(func(events []Event) {
+		received = append(received, events)
+	})
+
+	tx := collection.Begin()
+	tx.Add("c")
+	tx.Set(0, "z")
+	tx.Delete("b")
+
+	assert.Equal(test, []Element{"a", "b"}, collection.Elements(), "Staged operations shouldn't be applied before committing")
+	assert.Nil(test, tx.Commit(), "Unexpected error committing a transaction")
+	assert.Equal(test, []Element{"z", "c"}, collection.Elements(), "Wrong elements after committing a transaction")
+	assert.True(test, collection.Contains("c"), "Committed elements should be indexed")
+	assert.False(test, collection.Contains("a"), "Replaced elements shouldn't be indexed")
+	assert.Len(test, received, 1, "Committed operations should be notified together")
+	assert.Len(test, received[0], 3, "Every committed operation should be notified")
+
+	assert.Equal(test, ErrTransactionClosed, tx.Add("d"), "Committed transactions can't be used anymore")
+	assert.Equal(test, ErrTransactionClosed, tx.Commit(), "Committed transactions can't be committed again")
+}
+
+func TestTransactionFailedCommit(test *testing.T) {
+	collection := NewCollection([]int{1, 2})
+
+	tx := collection.Begin()
+	tx.Add(3)
+	tx.Delete(1)
+	tx.Add(3)
+
+	assert.Equal(test, ErrDuplicatedElement, tx.Commit(), "Failed operations should return their error on commit")
+	assert.Equal(test, []Element{1, 2}, collection.Elements(), "Failed commits should leave the collection untouched")
+	assert.False(test, collection.Contains(3), "Failed commits should leave the index untouched")
+
+	tx = collection.Begin()
+	tx.Set(5, 5)
+
+	assert.Equal(test, ErrIndexOutOfRange, tx.Commit(), "Positions out of range should return an error on commit")
+}
+
+func TestTransactionRollback(test *testing.T) {
+	collection := NewCollection([]int{1})
+
+	tx := collection.Begin()
+	tx.Add(2)
+
+	assert.Nil(test, tx.Rollback(), "Unexpected error rolling back a transaction")
+	assert.Equal(test, []Element{1}, collection.Elements(), "Rolled back operations shouldn't be applied")
+	assert.Equal(test, ErrTransactionClosed, tx.Commit(), "Rolled back transactions can't be committed")
+	assert.Equal(test, ErrTransactionClosed, tx.Rollback(), "Rolled back transactions can't be rolled back again")
+}
+
+func TestAtomicRangeMethods(test *testing.T) {
+	collection := NewCollection([]int{1, 2})
+
+	assert.Equal(test, &DuplicateError{Value: 1, Index: 2}, collection.AddRange([]int{3, 4, 1}), "Duplicated elements should return an error")
+	assert.Equal(test, &DuplicateError{Value: 3, Index: 1}, collection.AddRange([]int{3, 3}), "Duplicated elements in the range should return an error")
+	assert.ErrorIs(test, collection.AddRange([]Element{3, "4"}), ErrInvalidElementType, "Heterogeneous elements should return an error")
+	assert.Equal(test, []Element{1, 2}, collection.Elements(), "Failed AddRange calls should leave the collection untouched")
+
+	assert.Equal(test, &NotFoundError{Value: 5, Index: 1}, collection.DeleteRange([]int{1, 5}), "Non existing elements should return an error")
+	assert.Equal(test, &NotFoundError{Value: 1, Index: 1}, collection.DeleteRange([]int{1, 1}), "Repeated elements in the range should return an error")
+	assert.Equal(test, []Element{1, 2}, collection.Elements(), "Failed DeleteRange calls should leave the collection untouched")
+
+	empty := NewEmptyCollection()
+
+	assert.ErrorIs(test, empty.AddRange([]Element{1, "1"}), ErrInvalidElementType, "Heterogeneous elements should return an error on empty collections")
+	assert.True(test, empty.IsEmpty(), "Failed AddRange calls should leave empty collections untouched")
+}
@@ -0,0 +1,312 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// The datatypes/collection package provides new structures and
+// behaviours to the iteration of non-sorted unique element and homogeneous
+// lists accepting primitives types and complex user structs as well.
+
+// This part of package contains the type-parameterized collection
+
+package collection
+
+import (
+	"reflect"
+
+	"github.com/jaimelopez/datatypes/generic"
+	"github.com/jaimelopez/datatypes/internal/ranges"
+)
+
+// TypedCollection represents a non-sorted unique element list whose
+// homogeneity is guaranteed at compile time by its type parameter
+// Elements are compared using the equality function specified on instantiation.
+// The zero value is an empty collection ready to use which compares the elements
+// with the == operator, or with generic.Equal if they can't be compared with it
+type TypedCollection[T any] struct {
+	equal    func(T, T) bool
+	elements []T
+}
+
+// Add a single element to the collection
+// If the element is already stored in the collection it returns an error
+func (col *TypedCollection[T]) Add(element T) error {
+	if col.Contains(element) {
+		return ErrDuplicatedElement
+	}
+
+	col.elements = append(col.elements, element)
+
+	return nil
+}
+
+// AddRange inserts a range (slice) inside the collection
+// The range is added all or nothing, so if any element is duplicated
+// the collection is left untouched and a DuplicateError is returned,
+// or all of them joined if the JoinErrors option is specified
+func (col *TypedCollection[T]) AddRange(elements []T, options ...RangeOption) error {
+	var staged []T
+
+	err := checkTyped(elements, func(position int, element T) error {
+		if col.Contains(element) || col.containedIn(staged, element) {
+			return &DuplicateError{Value: element, Index: position}
+		}
+
+		staged = append(staged, element)
+
+		return nil
+	}, options)
+
+	if err != nil {
+		return err
+	}
+
+	col.elements = append(col.elements, elements...)
+
+	return nil
+}
+
+// AddCollection adds the elements contained in the parameter collection inside the instanced collection
+// It follows the same rules than AddRange
+func (col *TypedCollection[T]) AddCollection(collection *TypedCollection[T], options ...RangeOption) error {
+	return col.AddRange(collection.elements, options...)
+}
+
+// First returns the first element without removing it from the collection
+func (col *TypedCollection[T]) First() T {
+	return col.elements[0]
+}
+
+// Last returns the last element without removing it from the collection
+func (col *TypedCollection[T]) Last() T {
+	return col.elements[len(col.elements)-1]
+}
+
+// ElementAt returns the element in the specified position
+// Be aware that the order of elements could be changed constantly
+// such it's described in the Collection type
+func (col *TypedCollection[T]) ElementAt(position int) T {
+	return col.elements[position]
+}
+
+// Elements returns the stored collection elements as slice of this elements
+func (col *TypedCollection[T]) Elements() []T {
+	return col.elements
+}
+
+// Extract the first element and return it
+// Keep in mind that this method will modify the collection elements subtracting that element
+func (col *TypedCollection[T]) Extract() T {
+	element := col.First()
+	col.elements = col.elements[1:]
+
+	return element
+}
+
+// Set a new value for a specified index element
+// The element can't be already stored in another position of the collection
+// since, as Add does, it would break the uniqueness of its elements
+func (col *TypedCollection[T]) Set(position int, element T) error {
+	if !col.equals(col.elements[position], element) && col.Contains(element) {
+		return ErrDuplicatedElement
+	}
+
+	col.elements[position] = element
+
+	return nil
+}
+
+// Delete removes an specified already stored element
+// If it's not found the method will return an error
+func (col *TypedCollection[T]) Delete(element T) error {
+	for index, current := range col.elements {
+		if col.equals(current, element) {
+			col.elements = append(col.elements[:index], col.elements[index+1:]...)
+
+			return nil
+		}
+	}
+
+	return ErrElementNotFound
+}
+
+// DeleteRange removes all the found elements contained in the specified range (slice)
+// The range is deleted all or nothing, so if any element can't be deleted
+// the collection is left untouched and a NotFoundError is returned,
+// or all of them joined if the JoinErrors option is specified
+func (col *TypedCollection[T]) DeleteRange(elements []T, options ...RangeOption) error {
+	var staged []T
+
+	err := checkTyped(elements, func(position int, element T) error {
+		if !col.Contains(element) || col.containedIn(staged, element) {
+			return &NotFoundError{Value: element, Index: position}
+		}
+
+		staged = append(staged, element)
+
+		return nil
+	}, options)
+
+	if err != nil {
+		return err
+	}
+
+	for _, element := range elements {
+		col.Delete(element)
+	}
+
+	return nil
+}
+
+// DeleteCollection removes all the found elements contained in the specified
+// collection from the instaced collection
+// It follows the same rules than DeleteRange
+func (col *TypedCollection[T]) DeleteCollection(collection *TypedCollection[T], options ...RangeOption) error {
+	return col.DeleteRange(collection.elements, options...)
+}
+
+// Contains checks if the specified element is already existing in the collection
+func (col *TypedCollection[T]) Contains(element T) bool {
+	return col.containedIn(col.elements, element)
+}
+
+// ContainsAny checks if any of the parameter elements there are already contained in the collection
+func (col *TypedCollection[T]) ContainsAny(elements []T) bool {
+	for _, element := range elements {
+		if col.Contains(element) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Filter returns a element colecction filtering the elements with a function
+// If the functions return true the element will be filtered
+func (col *TypedCollection[T]) Filter(f func(T) bool) []T {
+	var results []T
+
+	for _, elem := range col.elements {
+		if !f(elem) {
+			continue
+		}
+
+		results = append(results, elem)
+	}
+
+	return results
+}
+
+// Size returns the number of elements inside the collection
+func (col *TypedCollection[T]) Size() int {
+	return len(col.elements)
+}
+
+// IsEmpty checks if the collection is empty or not
+func (col *TypedCollection[T]) IsEmpty() bool {
+	return col.Size() == 0
+}
+
+// Collection converts the typed collection to an untyped one
+// The returned collection doesn't share its storage with the typed one and,
+// as any other untyped collection, it will check the homogeneity of the elements
+func (col *TypedCollection[T]) Collection() (*Collection, error) {
+	collection := NewEmptyCollection()
+	err := collection.AddRange(col.elements)
+
+	return collection, err
+}
+
+// NewEmptyTypedCollection instances a new empty collection comparing
+// the elements with the == operator
+func NewEmptyTypedCollection[T comparable]() *TypedCollection[T] {
+	return NewEmptyTypedCollectionFunc(func(a, b T) bool { return a == b })
+}
+
+// NewTypedCollection allows to instance a new TypedCollection with a group of
+// comparable elements
+func NewTypedCollection[T comparable](elements []T) (*TypedCollection[T], error) {
+	collection := NewEmptyTypedCollection[T]()
+	err := collection.AddRange(elements)
+
+	return collection, err
+}
+
+// NewEmptyTypedCollectionFunc instances a new empty collection which uses
+// the specified function to check the equality between elements
+// It's intended for element types which can't be compared with == operator
+func NewEmptyTypedCollectionFunc[T any](equal func(T, T) bool) *TypedCollection[T] {
+	return &TypedCollection[T]{equal: equal}
+}
+
+// NewTypedCollectionFunc allows to instance a new TypedCollection with a group of
+// elements which are compared using the specified equality function
+func NewTypedCollectionFunc[T any](equal func(T, T) bool, elements []T) (*TypedCollection[T], error) {
+	collection := NewEmptyTypedCollectionFunc(equal)
+	err := collection.AddRange(elements)
+
+	return collection, err
+}
+
+// FromCollection converts an untyped collection to a TypedCollection of comparable elements
+// If any of the stored elements is not a T value it returns an error
+func FromCollection[T comparable](collection *Collection) (*TypedCollection[T], error) {
+	return FromCollectionFunc(collection, func(a, b T) bool { return a == b })
+}
+
+// FromCollectionFunc converts an untyped collection to a TypedCollection
+// which uses the specified equality function
+// If any of the stored elements is not a T value it returns an error
+func FromCollectionFunc[T any](collection *Collection, equal func(T, T) bool) (*TypedCollection[T], error) {
+	typed := NewEmptyTypedCollectionFunc(equal)
+
+	for _, element := range collection.elements {
+		value, ok := element.(T)
+
+		if !ok {
+			return nil, ErrInvalidElementType
+		}
+
+		typed.elements = append(typed.elements, value)
+	}
+
+	return typed, nil
+}
+
+// equals compares two elements with the equality function of the collection,
+// falling back to the == operator, or generic.Equal, for the zero value
+func (col *TypedCollection[T]) equals(first T, second T) bool {
+	if col.equal == nil {
+		if reflect.ValueOf(first).Comparable() && reflect.ValueOf(second).Comparable() {
+			return any(first) == any(second)
+		}
+
+		return generic.Equal(first, second)
+	}
+
+	return col.equal(first, second)
+}
+
+// containedIn checks if the element is in the list using the equality function of the collection
+func (col *TypedCollection[T]) containedIn(elements []T, element T) bool {
+	for _, current := range elements {
+		if col.equals(current, element) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkTyped checks if every element of a range passes the check function,
+// which returns the failure of an element or nil, collecting the failures as the options specify
+func checkTyped[T any](elements []T, check func(int, T) error, options []RangeOption) error {
+	failures := ranges.NewFailures(options)
+
+	for position, element := range elements {
+		if err := check(position, element); err != nil && !failures.Add(err) {
+			break
+		}
+	}
+
+	return failures.Err()
+}
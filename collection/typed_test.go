@@ -0,0 +1,134 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// The datatypes/collection package provides new structures and
+// behaviours to the iteration of non-sorted unique element and homogeneous
+// lists accepting primitives types and complex user structs as well.
+
+// This part of package contains the tests for the type-parameterized collection
+
+package collection
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypedAddMethod(test *testing.T) {
+	collection := NewEmptyTypedCollection[string]()
+
+	assert.Nil(test, collection.Add("first element"), "Unexpected error adding a typed element")
+	assert.Len(test, collection.elements, 1, "Wrong behaviour adding a typed element")
+	assert.Equal(test, ErrDuplicatedElement, collection.Add("first element"), "Duplicated typed elements should return an error")
+}
+
+func TestTypedAddRangeMethod(test *testing.T) {
+	collection := NewEmptyTypedCollection[int]()
+
+	assert.Nil(test, collection.AddRange([]int{1, 2, 3}), "Unexpected error adding a typed range")
+	assert.Equal(test, []int{1, 2, 3}, collection.Elements(), "Wrong elements adding a typed range")
+	assert.Equal(test, &DuplicateError{Value: 1, Index: 1}, collection.AddRange([]int{4, 1}), "Duplicated typed elements in range should return an error")
+	assert.Equal(test, []int{1, 2, 3}, collection.Elements(), "Failed AddRange calls should leave the typed collection untouched")
+
+	err := collection.AddRange([]int{5, 5, 2}, JoinErrors())
+
+	assert.Equal(test, errors.Join(
+		&DuplicateError{Value: 5, Index: 1},
+		&DuplicateError{Value: 2, Index: 2},
+	), err, "JoinErrors option should return all the failures of typed AddRange")
+	assert.Equal(test, []int{1, 2, 3}, collection.Elements(), "Failed AddRange calls should leave the typed collection untouched")
+}
+
+func TestTypedSetMethod(test *testing.T) {
+	collection, _ := NewTypedCollection([]string{"a", "b"})
+
+	assert.Nil(test, collection.Set(0, "a"), "Setting the same element in its position shouldn't return an error")
+	assert.Equal(test, ErrDuplicatedElement, collection.Set(0, "b"), "Set method should keep the typed elements unique")
+	assert.Nil(test, collection.Set(0, "c"), "Unexpected error setting a typed element")
+	assert.Equal(test, []string{"c", "b"}, collection.Elements(), "Wrong elements after setting a typed element")
+}
+
+func TestTypedZeroValue(test *testing.T) {
+	var collection TypedCollection[[]int]
+
+	assert.Nil(test, collection.Add([]int{1}), "Unexpected error adding to a zero value typed collection")
+	assert.Equal(test, ErrDuplicatedElement, collection.Add([]int{1}), "Zero value typed collections should compare the elements structurally")
+	assert.True(test, collection.Contains([]int{1}), "Contains return a false negative in a zero value typed collection")
+
+	var pointers TypedCollection[*int]
+	first, second := 1, 1
+
+	assert.Nil(test, pointers.Add(&first), "Unexpected error adding to a zero value typed collection")
+	assert.Nil(test, pointers.Add(&second), "Zero value typed collections should compare comparable elements with ==")
+}
+
+func TestTypedExtractMethod(test *testing.T) {
+	collection, _ := NewTypedCollection([]string{"first element", "second element"})
+
+	assert.Exactly(test, "first element", collection.Extract(), "Wrong extracted typed element")
+	assert.Equal(test, []string{"second element"}, collection.Elements(), "Wrong remained typed elements after extraction")
+}
+
+func TestTypedDeleteMethod(test *testing.T) {
+	collection, _ := NewTypedCollection([]int{1, 2, 3})
+
+	assert.Nil(test, collection.Delete(2), "Unexpected error deleting a typed element")
+	assert.Equal(test, []int{1, 3}, collection.Elements(), "Wrong remained typed elements after deletion")
+	assert.Equal(test, ErrElementNotFound, collection.Delete(2), "Not found typed elements should return an error on deletion")
+	assert.Equal(test, &NotFoundError{Value: 2, Index: 1}, collection.DeleteRange([]int{1, 2}), "Not found typed elements in range should return an error")
+	assert.Equal(test, []int{1, 3}, collection.Elements(), "Failed DeleteRange calls should leave the typed collection untouched")
+	assert.Nil(test, collection.DeleteRange([]int{1, 3}), "Unexpected error deleting a typed range")
+	assert.True(test, collection.IsEmpty(), "Typed collection should be empty after deleting all the elements")
+}
+
+func TestTypedContainsMethod(test *testing.T) {
+	collection, _ := NewTypedCollection([]string{"first element", "second element"})
+
+	assert.True(test, collection.Contains("first element"), "Contains return a false negative with existent typed elements")
+	assert.False(test, collection.Contains("third element"), "Contains return a false positive with inexistent typed elements")
+	assert.True(test, collection.ContainsAny([]string{"third element", "second element"}), "ContainsAny return a false negative with existent typed elements")
+}
+
+func TestTypedFilterMethod(test *testing.T) {
+	collection, _ := NewTypedCollection([]string{"first element", "second element", "third element"})
+
+	matches := collection.Filter(func(elem string) bool {
+		return strings.Contains(elem, "second")
+	})
+
+	assert.Equal(test, []string{"second element"}, matches, "Wrong filtered typed elements!")
+}
+
+func TestTypedCollectionFunc(test *testing.T) {
+	equal := func(a, b []int) bool { return reflect.DeepEqual(a, b) }
+
+	collection, err := NewTypedCollectionFunc(equal, [][]int{{1, 2}, {3}})
+
+	assert.Nil(test, err, "Unexpected error instancing a typed collection with equality function")
+	assert.True(test, collection.Contains([]int{1, 2}), "Equality function not used on Contains method")
+	assert.Equal(test, ErrDuplicatedElement, collection.Add([]int{3}), "Equality function not used on Add method")
+}
+
+func TestTypedCollectionConversion(test *testing.T) {
+	typed, _ := NewTypedCollection([]string{"first element", "second element"})
+
+	untyped, err := typed.Collection()
+
+	assert.Nil(test, err, "Unexpected error converting a typed collection")
+	assert.Equal(test, []Element{"first element", "second element"}, untyped.Elements(), "Wrong elements converting a typed collection")
+
+	converted, err := FromCollection[string](untyped)
+
+	assert.Nil(test, err, "Unexpected error converting an untyped collection")
+	assert.Equal(test, typed.Elements(), converted.Elements(), "Wrong elements converting an untyped collection")
+
+	wrongConverted, err := FromCollection[int](untyped)
+
+	assert.Equal(test, ErrInvalidElementType, err, "Converting to a wrong element type should return an error")
+	assert.Nil(test, wrongConverted, "Converting to a wrong element type should return nil")
+}
@@ -4,6 +4,7 @@
 //  @datatypes/collection : Provides a new single-value list struct simplifying the iteration over it
 //  @datatypes/dictionary : Provides a new object type which allows to treat them like <Key,Value> list
 //  @datatypes/generic : Packages which includes some functionalities to treat 'generic' objects
+//  @datatypes/stream : Provides lazy pipelines over collections, dictionaries and any other sequence
 //  @datatypes/string : Simple package which encapsulates some basic operations over strings
 package datatypes
 
@@ -15,6 +16,8 @@ import (
 	_ "github.com/jaimelopez/datatypes/dictionary"
 	// generic package
 	_ "github.com/jaimelopez/datatypes/generic"
+	// stream package
+	_ "github.com/jaimelopez/datatypes/stream"
 	// string package
 	_ "github.com/jaimelopez/datatypes/string"
 )
@@ -0,0 +1,479 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// Package datatypes/dictionary provides an easy dictionary (key => value) homogeneous
+// struct management, making the iteration of a unique-key lists more powerful,
+// simple and clean, accepting primitives types and complex user structs as well.
+
+// This part of package contains the bidirectional dictionary behaviour
+
+package dictionary
+
+import (
+	"reflect"
+
+	"github.com/jaimelopez/datatypes/generic"
+)
+
+// BiDictionary represents a dictionary whose values are unique as well as its keys,
+// so the elements can be looked up by key and by value in constant time
+// Keys are compared with the == operator as the Dictionary ones, while values are
+// compared with generic.Equal as Dictionary.ContainsValue does, so values like slices
+// or maps can be stored and pointer values are equal if they point to equal values.
+// Values are indexed by their content, so the pointed values mustn't be modified while stored.
+// The zero value is an empty dictionary ready to use
+type BiDictionary struct {
+	forward  *biIndex
+	backward *biIndex
+}
+
+// biIndex maps the elements of one side of a bidirectional dictionary to their counterparts
+// Elements compared with the == operator are used directly as map keys, as well as the
+// deeply compared ones whose type gives the same result with ==. The rest of them are
+// bucketed by generic.Hash and compared with generic.Equal
+type biIndex struct {
+	definition reflect.Type
+	deep       bool
+	direct     map[interface{}]interface{}
+	buckets    map[uint64][]*KeyValueElement
+	size       int
+}
+
+// Add a key-value element to the dictionary
+// It follows the same rules than the Add method of Dictionary,
+// but if the value is already stored with another key it returns ErrDuplicatedValue
+func (dic *BiDictionary) Add(key KeyElement, value ValueElement) error {
+	dic.initialize()
+
+	if err := dic.check(key, value); err != nil {
+		return err
+	}
+
+	if dic.forward.contains(key) {
+		return ErrDuplicatedKey
+	}
+
+	if dic.backward.contains(value) {
+		return ErrDuplicatedValue
+	}
+
+	dic.forward.put(key, value)
+	dic.backward.put(value, key)
+
+	return nil
+}
+
+// AddKeyValueElement adds an composed element KeyValueElement to the dictionary
+func (dic *BiDictionary) AddKeyValueElement(element KeyValueElement) error {
+	return dic.Add(element.Key, element.Value)
+}
+
+// AddRange inserts a range (slice) of KeyValueElement inside the dictionary
+// It follows the same rules than the AddRange method of Dictionary,
+// but a DuplicateValueError is returned for the values already stored with another key
+func (dic *BiDictionary) AddRange(elements []KeyValueElement, options ...RangeOption) error {
+	dic.initialize()
+
+	if len(elements) == 0 {
+		return nil
+	}
+
+	keyDefinition, valueDefinition := dic.forward.definition, dic.backward.definition
+
+	if dic.IsEmpty() {
+		keyDefinition, valueDefinition = reflect.TypeOf(elements[0].Key), reflect.TypeOf(elements[0].Value)
+	}
+
+	if !dic.forward.acceptsType(keyDefinition) || !dic.backward.acceptsType(valueDefinition) {
+		return ErrInvalidKeyValueElementType
+	}
+
+	staged := &BiDictionary{forward: newBiIndex(dic.forward.deep), backward: newBiIndex(dic.backward.deep)}
+
+	err := checkElements(elements, keyDefinition, valueDefinition, func(position int, element KeyValueElement) error {
+		if !dic.forward.accepts(element.Key) || !dic.backward.accepts(element.Value) {
+			return ErrInvalidKeyValueElementType
+		}
+
+		if dic.forward.contains(element.Key) || staged.forward.contains(element.Key) {
+			return &DuplicateError{Key: element.Key, Index: position}
+		}
+
+		if dic.backward.contains(element.Value) || staged.backward.contains(element.Value) {
+			return &DuplicateValueError{Value: element.Value, Index: position}
+		}
+
+		staged.forward.put(element.Key, element.Value)
+		staged.backward.put(element.Value, element.Key)
+
+		return nil
+	}, options)
+
+	if err != nil {
+		return err
+	}
+
+	for _, element := range elements {
+		dic.AddKeyValueElement(element)
+	}
+
+	return nil
+}
+
+// ForcePut sets the value of the key evicting any element which conflicts with it,
+// that is, the previous value of the key and the previous key of the value
+// It only returns an error if the key or the value have invalid types
+func (dic *BiDictionary) ForcePut(key KeyElement, value ValueElement) error {
+	dic.initialize()
+
+	if err := dic.check(key, value); err != nil {
+		return err
+	}
+
+	if previous, err := dic.KeyOf(value); err == nil {
+		dic.Delete(previous)
+	}
+
+	dic.Delete(key)
+
+	return dic.Add(key, value)
+}
+
+// Element returns the value of the specified key
+func (dic *BiDictionary) Element(key KeyElement) (*ValueElement, error) {
+	dic.initialize()
+
+	value, exists := dic.forward.get(key)
+
+	if !exists {
+		return nil, ErrElementNotFound
+	}
+
+	element := ValueElement(value)
+
+	return &element, nil
+}
+
+// KeyOf returns the key of the specified value
+// If the value is not found it returns ErrElementNotFound
+func (dic *BiDictionary) KeyOf(value ValueElement) (KeyElement, error) {
+	dic.initialize()
+
+	key, exists := dic.backward.get(value)
+
+	if !exists {
+		return nil, ErrElementNotFound
+	}
+
+	return key, nil
+}
+
+// Set a new value for an already stored key
+// If the value is already stored with another key it returns ErrDuplicatedValue
+func (dic *BiDictionary) Set(key KeyElement, value ValueElement) error {
+	dic.initialize()
+
+	if err := dic.check(key, value); err != nil {
+		return err
+	}
+
+	old, exists := dic.forward.get(key)
+
+	if !exists {
+		return ErrElementNotFound
+	}
+
+	if previous, exists := dic.backward.get(value); exists && !dic.forward.equal(previous, key) {
+		return ErrDuplicatedValue
+	}
+
+	dic.backward.delete(old)
+	dic.backward.put(value, key)
+	dic.forward.put(key, value)
+
+	return nil
+}
+
+// Delete an specified already stored key and its value
+// If it's not found the method will return an error
+func (dic *BiDictionary) Delete(key KeyElement) error {
+	dic.initialize()
+
+	value, exists := dic.forward.get(key)
+
+	if !exists {
+		return ErrElementNotFound
+	}
+
+	dic.forward.delete(key)
+	dic.backward.delete(value)
+
+	return nil
+}
+
+// Inverse returns a view of the dictionary whose keys are the values and vice versa
+// The view shares the elements with the instanced dictionary,
+// so the changes done through any of them are seen by the other one
+// Its keys and values keep being compared as the values and keys of the instanced dictionary
+func (dic *BiDictionary) Inverse() *BiDictionary {
+	dic.initialize()
+
+	return &BiDictionary{forward: dic.backward, backward: dic.forward}
+}
+
+// Elements returns a copy of the stored elements
+// If the keys can't be used as map keys, like in the inverse view of a dictionary
+// whose values are slices, it returns nil, so Entries should be used instead
+func (dic *BiDictionary) Elements() *KeyValueMap {
+	dic.initialize()
+
+	elements := make(KeyValueMap, dic.Size())
+
+	for _, element := range dic.Entries() {
+		if !isComparableKey(element.Key) {
+			return nil
+		}
+
+		elements[element.Key] = element.Value
+	}
+
+	return &elements
+}
+
+// Entries returns all the key-value elements of the dictionary
+func (dic *BiDictionary) Entries() []KeyValueElement {
+	dic.initialize()
+
+	entries := make([]KeyValueElement, 0, dic.Size())
+
+	dic.forward.each(func(key interface{}, value interface{}) {
+		entries = append(entries, KeyValueElement{key, value})
+	})
+
+	return entries
+}
+
+// Keys returns all the keys in the dictionary as a list of KeyElement
+func (dic *BiDictionary) Keys() []KeyElement {
+	dic.initialize()
+
+	keys := make([]KeyElement, 0, dic.Size())
+
+	dic.forward.each(func(key interface{}, _ interface{}) {
+		keys = append(keys, key)
+	})
+
+	return keys
+}
+
+// Values returns all the values in the dictionary as a list of ValueElement
+func (dic *BiDictionary) Values() []ValueElement {
+	dic.initialize()
+
+	values := make([]ValueElement, 0, dic.Size())
+
+	dic.forward.each(func(_ interface{}, value interface{}) {
+		values = append(values, value)
+	})
+
+	return values
+}
+
+// Contains checks if the specified key element is already existing in the dictionary
+func (dic *BiDictionary) Contains(key KeyElement) bool {
+	dic.initialize()
+
+	return dic.forward.contains(key)
+}
+
+// ContainsValue checks if the specified value element exists in the dictionary
+// Unlike the Dictionary one, the lookup is done in constant time
+func (dic *BiDictionary) ContainsValue(value ValueElement) bool {
+	dic.initialize()
+
+	return dic.backward.contains(value)
+}
+
+// Size returns the number of elements inside the dictionary
+func (dic *BiDictionary) Size() int {
+	dic.initialize()
+
+	return dic.forward.size
+}
+
+// IsEmpty checks if the dictionary is empty or not
+func (dic *BiDictionary) IsEmpty() bool {
+	return dic.Size() == 0
+}
+
+// check verifies the key and the value could be stored in the dictionary
+func (dic *BiDictionary) check(key KeyElement, value ValueElement) error {
+	if !dic.IsEmpty() && (dic.forward.definition != reflect.TypeOf(key) || dic.backward.definition != reflect.TypeOf(value)) {
+		return ErrInvalidKeyValueElementType
+	}
+
+	if !dic.forward.accepts(key) || !dic.backward.accepts(value) {
+		return ErrInvalidKeyValueElementType
+	}
+
+	return nil
+}
+
+// initialize creates the indexes of the zero value
+func (dic *BiDictionary) initialize() {
+	if dic.forward == nil {
+		dic.forward, dic.backward = newBiIndex(false), newBiIndex(true)
+	}
+}
+
+// put stores the counterpart of the element, replacing the previous one if it's already stored
+func (idx *biIndex) put(element interface{}, counterpart interface{}) {
+	if idx.size == 0 {
+		idx.definition = reflect.TypeOf(element)
+	}
+
+	if !idx.hashed(element) {
+		if _, exists := idx.direct[element]; !exists {
+			idx.size++
+		}
+
+		idx.direct[element] = counterpart
+
+		return
+	}
+
+	if entry := idx.entry(element); entry != nil {
+		entry.Value = counterpart
+
+		return
+	}
+
+	hash := generic.Hash(element)
+	idx.buckets[hash] = append(idx.buckets[hash], &KeyValueElement{element, counterpart})
+	idx.size++
+}
+
+// get returns the counterpart of the element and whether it's stored
+func (idx *biIndex) get(element interface{}) (interface{}, bool) {
+	if !idx.accepts(element) {
+		return nil, false
+	}
+
+	if !idx.hashed(element) {
+		counterpart, exists := idx.direct[element]
+
+		return counterpart, exists
+	}
+
+	if entry := idx.entry(element); entry != nil {
+		return entry.Value, true
+	}
+
+	return nil, false
+}
+
+func (idx *biIndex) contains(element interface{}) bool {
+	_, exists := idx.get(element)
+
+	return exists
+}
+
+func (idx *biIndex) delete(element interface{}) {
+	if !idx.hashed(element) {
+		if _, exists := idx.direct[element]; exists {
+			delete(idx.direct, element)
+			idx.size--
+		}
+
+		return
+	}
+
+	hash := generic.Hash(element)
+	bucket := idx.buckets[hash]
+
+	for position, current := range bucket {
+		if !generic.Equal(current.Key, element) {
+			continue
+		}
+
+		if len(bucket) == 1 {
+			delete(idx.buckets, hash)
+		} else {
+			idx.buckets[hash] = append(bucket[:position:position], bucket[position+1:]...)
+		}
+
+		idx.size--
+
+		return
+	}
+}
+
+// entry returns the bucket entry of the element or nil if it's not stored
+func (idx *biIndex) entry(element interface{}) *KeyValueElement {
+	for _, current := range idx.buckets[generic.Hash(element)] {
+		if generic.Equal(current.Key, element) {
+			return current
+		}
+	}
+
+	return nil
+}
+
+// each calls the function with every stored element and its counterpart
+func (idx *biIndex) each(f func(interface{}, interface{})) {
+	for element, counterpart := range idx.direct {
+		f(element, counterpart)
+	}
+
+	for _, bucket := range idx.buckets {
+		for _, entry := range bucket {
+			f(entry.Key, entry.Value)
+		}
+	}
+}
+
+func (idx *biIndex) equal(first interface{}, second interface{}) bool {
+	if idx.deep {
+		return generic.Equal(first, second)
+	}
+
+	return first == second
+}
+
+// hashed checks if the element is bucketed by its hash instead of used as a map key
+func (idx *biIndex) hashed(element interface{}) bool {
+	return idx.deep && !generic.IsComparable(reflect.TypeOf(element))
+}
+
+// accepts checks if the element can be stored, since the ones compared with
+// the == operator which can't be used as map keys, like slices or maps, would panic
+func (idx *biIndex) accepts(element interface{}) bool {
+	return idx.deep || isComparableKey(element)
+}
+
+func (idx *biIndex) acceptsType(definition reflect.Type) bool {
+	return idx.deep || definition == nil || definition.Comparable()
+}
+
+func newBiIndex(deep bool) *biIndex {
+	return &biIndex{
+		deep:    deep,
+		direct:  make(map[interface{}]interface{}),
+		buckets: make(map[uint64][]*KeyValueElement),
+	}
+}
+
+// NewEmptyBiDictionary instances a new empty bidirectional dictionary
+func NewEmptyBiDictionary() *BiDictionary {
+	return &BiDictionary{forward: newBiIndex(false), backward: newBiIndex(true)}
+}
+
+// NewBiDictionary allows to instance a new BiDictionary with a group of key-value elements
+// Both keys and values must be unique
+func NewBiDictionary(elements []KeyValueElement) (*BiDictionary, error) {
+	dictionary := NewEmptyBiDictionary()
+	err := dictionary.AddRange(elements)
+
+	return dictionary, err
+}
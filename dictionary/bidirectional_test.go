@@ -0,0 +1,179 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// Package datatypes/dictionary provides an easy dictionary (key => value) homogeneous
+// struct management, making the iteration of a unique-key lists more powerful,
+// simple and clean, accepting primitives types and complex user structs as well.
+
+// This part of package contains the tests for the bidirectional dictionary behaviour
+
+package dictionary
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBiDictionaryAddMethod(test *testing.T) {
+	dictionary := NewEmptyBiDictionary()
+
+	assert.Nil(test, dictionary.Add(1, "one"), "Unexpected error adding an element")
+	assert.Equal(test, ErrDuplicatedKey, dictionary.Add(1, "uno"), "Duplicated keys should return an error")
+	assert.Equal(test, ErrDuplicatedValue, dictionary.Add(2, "one"), "Duplicated values should return an error")
+	assert.Equal(test, ErrInvalidKeyValueElementType, dictionary.Add(2, 2), "Values must be homogeneous")
+	assert.Equal(test, ErrInvalidKeyValueElementType, NewEmptyBiDictionary().Add([]int{1}, 1), "Non-comparable keys should return an error")
+	assert.Equal(test, 1, dictionary.Size(), "Failed additions shouldn't be stored")
+}
+
+func TestBiDictionaryAddRangeMethod(test *testing.T) {
+	dictionary, err := NewBiDictionary([]KeyValueElement{{Key: 1, Value: "one"}, {Key: 2, Value: "two"}})
+
+	assert.Nil(test, err, "Unexpected error instancing a bidirectional dictionary")
+
+	err = dictionary.AddRange([]KeyValueElement{{Key: 3, Value: "three"}, {Key: 4, Value: "three"}})
+
+	assert.Equal(test, &DuplicateValueError{Value: "three", Index: 1}, err, "Duplicated values in the range should return an error")
+	assert.ErrorIs(test, err, ErrDuplicatedValue, "Duplicated value errors should be considered ErrDuplicatedValue")
+	assert.Equal(test, KeyValueMap{1: "one", 2: "two"}, *dictionary.Elements(), "Failed AddRange calls should leave the dictionary untouched")
+	assert.False(test, dictionary.ContainsValue("three"), "Failed AddRange calls should leave the values untouched")
+
+	err = dictionary.AddRange([]KeyValueElement{{Key: 1, Value: "uno"}, {Key: 3, Value: 3}, {Key: 4, Value: "two"}}, JoinErrors())
+
+	assert.Equal(test, errors.Join(
+		&DuplicateError{Key: 1, Index: 0},
+		&TypeMismatchError{Expected: reflect.TypeOf(""), Actual: reflect.TypeOf(0), Index: 1},
+		&DuplicateValueError{Value: "two", Index: 2},
+	), err, "JoinErrors option should return all the failures of bidirectional AddRange")
+	assert.Equal(test, 2, dictionary.Size(), "Failed AddRange calls should leave the dictionary untouched")
+	assert.Equal(test, ErrInvalidKeyValueElementType, NewEmptyBiDictionary().AddRange([]KeyValueElement{{Key: []int{1}, Value: 1}}), "Non-comparable keys in range should return an error")
+
+	slices := NewEmptyBiDictionary()
+	err = slices.AddRange([]KeyValueElement{{Key: 1, Value: []int{1}}, {Key: 2, Value: []int{1}}})
+
+	assert.Equal(test, &DuplicateValueError{Value: []int{1}, Index: 1}, err, "Deeply equal values in the range should return an error")
+	assert.True(test, slices.IsEmpty(), "Failed AddRange calls should leave the dictionary untouched")
+}
+
+func TestBiDictionaryZeroValue(test *testing.T) {
+	var dictionary BiDictionary
+
+	assert.True(test, dictionary.IsEmpty(), "Zero value bidirectional dictionaries should be empty")
+	assert.False(test, dictionary.ContainsValue("one"), "ContainsValue return a false positive in a zero value dictionary")
+	assert.Nil(test, dictionary.Add(1, "one"), "Unexpected error adding to a zero value dictionary")
+
+	key, err := dictionary.KeyOf("one")
+
+	assert.Nil(test, err, "Unexpected error looking up a value of a zero value dictionary")
+	assert.Equal(test, 1, key, "Wrong key of the value in a zero value dictionary")
+}
+
+func TestBiDictionaryPointerValues(test *testing.T) {
+	first, second := &struct{ name string }{"one"}, &struct{ name string }{"one"}
+	dictionary := NewEmptyBiDictionary()
+
+	assert.Nil(test, dictionary.Add(1, first), "Unexpected error adding a pointer value")
+	assert.Equal(test, ErrDuplicatedValue, dictionary.Add(2, second), "Pointers to equal values should be the same value")
+	assert.True(test, dictionary.ContainsValue(second), "Pointer values should be looked up by the pointed value")
+
+	key, err := dictionary.KeyOf(&struct{ name string }{"one"})
+
+	assert.Nil(test, err, "Unexpected error looking up a pointer value")
+	assert.Equal(test, 1, key, "Wrong key of a pointer value")
+	assert.Nil(test, dictionary.Set(1, second), "Setting a value equal to the current one shouldn't be a duplicate")
+}
+
+func TestBiDictionaryNonComparableValues(test *testing.T) {
+	dictionary, err := NewBiDictionary([]KeyValueElement{{Key: 1, Value: []int{1}}, {Key: 2, Value: []int{2}}})
+
+	assert.Nil(test, err, "Unexpected error storing slice values")
+	assert.True(test, dictionary.ContainsValue([]int{2}), "Slice values should be looked up by content")
+	assert.Equal(test, ErrDuplicatedValue, dictionary.Add(3, []int{1}), "Deeply equal values should be duplicated")
+
+	key, _ := dictionary.KeyOf([]int{1})
+
+	assert.Equal(test, 1, key, "Wrong key of a slice value")
+	assert.Nil(test, dictionary.Set(1, []int{3}), "Unexpected error setting a slice value")
+	assert.False(test, dictionary.ContainsValue([]int{1}), "Replaced values shouldn't be contained")
+	assert.Nil(test, dictionary.Delete(2), "Unexpected error deleting a slice value")
+	assert.False(test, dictionary.ContainsValue([]int{2}), "Deleted values shouldn't be contained")
+	assert.Equal(test, []KeyValueElement{{Key: 1, Value: []int{3}}}, dictionary.Entries(), "Wrong entries with slice values")
+
+	inverse := dictionary.Inverse()
+
+	assert.True(test, inverse.Contains([]int{3}), "Inverse views should look up the slice keys by content")
+	assert.Nil(test, inverse.Elements(), "Inverse views with slice keys can't return their elements as a map")
+
+	maps := NewEmptyBiDictionary()
+
+	assert.Nil(test, maps.Add("a", map[string]int{"x": 1}), "Unexpected error storing a map value")
+	assert.True(test, maps.ContainsValue(map[string]int{"x": 1}), "Map values should be looked up by content")
+}
+
+func TestBiDictionaryLookups(test *testing.T) {
+	dictionary, _ := NewBiDictionary([]KeyValueElement{{Key: 1, Value: "one"}, {Key: 2, Value: "two"}})
+
+	value, err := dictionary.Element(2)
+
+	assert.Nil(test, err, "Unexpected error looking up a key")
+	assert.Equal(test, "two", *value, "Wrong value of the key")
+
+	key, err := dictionary.KeyOf("one")
+
+	assert.Nil(test, err, "Unexpected error looking up a value")
+	assert.Equal(test, 1, key, "Wrong key of the value")
+
+	_, err = dictionary.KeyOf("three")
+
+	assert.Equal(test, ErrElementNotFound, err, "Missing values should return an error")
+	assert.True(test, dictionary.ContainsValue("two"), "Stored values should be contained")
+	assert.False(test, dictionary.ContainsValue([]int{1}), "Values of other types shouldn't be contained")
+}
+
+func TestBiDictionarySetAndDeleteMethods(test *testing.T) {
+	dictionary, _ := NewBiDictionary([]KeyValueElement{{Key: 1, Value: "one"}, {Key: 2, Value: "two"}})
+
+	assert.Equal(test, ErrDuplicatedValue, dictionary.Set(1, "two"), "Setting a value of another key should return an error")
+	assert.Equal(test, ErrElementNotFound, dictionary.Set(3, "three"), "Setting a missing key should return an error")
+	assert.Nil(test, dictionary.Set(1, "uno"), "Unexpected error setting a value")
+	assert.False(test, dictionary.ContainsValue("one"), "Replaced values shouldn't be contained")
+
+	key, _ := dictionary.KeyOf("uno")
+
+	assert.Equal(test, 1, key, "Set values should be looked up by value")
+
+	assert.Nil(test, dictionary.Delete(2), "Unexpected error deleting a key")
+	assert.False(test, dictionary.ContainsValue("two"), "Deleted values shouldn't be contained")
+	assert.Equal(test, ErrElementNotFound, dictionary.Delete(2), "Deleting a missing key should return an error")
+}
+
+func TestBiDictionaryForcePutMethod(test *testing.T) {
+	dictionary, _ := NewBiDictionary([]KeyValueElement{{Key: 1, Value: "one"}, {Key: 2, Value: "two"}})
+
+	assert.Nil(test, dictionary.ForcePut(1, "two"), "Unexpected error forcing an element")
+	assert.Equal(test, KeyValueMap{1: "two"}, *dictionary.Elements(), "ForcePut method should evict the conflicting elements")
+	assert.Nil(test, dictionary.ForcePut(3, "three"), "Unexpected error forcing a new element")
+	assert.Equal(test, ErrInvalidKeyValueElementType, dictionary.ForcePut("4", "four"), "ForcePut method should check the types")
+	assert.Equal(test, 2, dictionary.Size(), "Wrong size after forcing elements")
+}
+
+func TestBiDictionaryInverseMethod(test *testing.T) {
+	dictionary, _ := NewBiDictionary([]KeyValueElement{{Key: 1, Value: "one"}})
+	inverse := dictionary.Inverse()
+
+	value, _ := inverse.Element("one")
+
+	assert.Equal(test, 1, *value, "Inverse view should map the values to the keys")
+
+	inverse.Add("two", 2)
+
+	assert.True(test, dictionary.ContainsValue("two"), "Changes in the inverse view should be seen by the dictionary")
+
+	dictionary.Delete(1)
+
+	assert.False(test, inverse.Contains("one"), "Changes in the dictionary should be seen by the inverse view")
+	assert.Same(test, dictionary.forward, inverse.Inverse().forward, "Inverse of the inverse should share the elements")
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// Package datatypes/dictionary provides an easy dictionary (key => value) homogeneous
+// struct management, making the iteration of a unique-key lists more powerful,
+// simple and clean, accepting primitives types and complex user structs as well.
+
+// This part of package contains the binary encoding and decoding of dictionaries
+
+package dictionary
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"sort"
+
+	"github.com/jaimelopez/datatypes/generic"
+	"github.com/jaimelopez/datatypes/internal/codec"
+)
+
+// GobEncode encodes the elements of the dictionary with encoding/gob
+// Any key and value types supported by gob can be encoded, although user types
+// must be registered with gob.Register as they're stored as interface values
+func (dic *Dictionary) GobEncode() ([]byte, error) {
+	elements := make([]KeyValueElement, 0, dic.Size())
+
+	for key, value := range dic.elements {
+		elements = append(elements, KeyValueElement{Key: key, Value: value})
+	}
+
+	var buffer bytes.Buffer
+
+	if err := gob.NewEncoder(&buffer).Encode(elements); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// GobDecode decodes the elements encoded by GobEncode replacing the elements of the dictionary
+// If any element can't be stored it returns the error and the dictionary is left untouched
+func (dic *Dictionary) GobDecode(data []byte) error {
+	var elements []KeyValueElement
+
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&elements); err != nil {
+		return err
+	}
+
+	return dic.replace(elements)
+}
+
+// MarshalBinary encodes the dictionary in a compact and versioned binary format
+// which stores the types of the keys and values and a checksum of the data
+// Only booleans, numbers and strings are supported, otherwise it returns ErrUnsupportedType.
+// The elements are encoded sorted by key, so equal dictionaries are always encoded the same way
+func (dic *Dictionary) MarshalBinary() ([]byte, error) {
+	encoder, err := codec.NewEncoder(dic.keyDefinition, dic.valueDefinition)
+
+	if err != nil {
+		return nil, err
+	}
+
+	keys := dic.Keys()
+	ordered := generic.IsOrdered(dic.keyDefinition)
+
+	// Booleans and complex numbers have no natural order so they're sorted by their text
+	sort.Slice(keys, func(first int, second int) bool {
+		if ordered {
+			return generic.Compare(keys[first], keys[second]) < 0
+		}
+
+		return fmt.Sprint(keys[first]) < fmt.Sprint(keys[second])
+	})
+
+	for _, key := range keys {
+		if err := encoder.Encode(key); err != nil {
+			return nil, err
+		}
+
+		if err := encoder.Encode(dic.elements[key]); err != nil {
+			return nil, err
+		}
+	}
+
+	return encoder.Bytes(), nil
+}
+
+// UnmarshalBinary decodes the data encoded by MarshalBinary replacing the elements of the dictionary
+// Named types must be registered with DecodeAs to be decoded. If the data is corrupted
+// it returns ErrCorruptedData and the dictionary is left untouched
+func (dic *Dictionary) UnmarshalBinary(data []byte) error {
+	decoder, err := codec.NewDecoder(data, dic.keyDecoding, dic.valueDecoding)
+
+	if err != nil {
+		return err
+	}
+
+	var elements []KeyValueElement
+
+	for decoder.More() {
+		key, err := decoder.Decode()
+
+		if err != nil {
+			return err
+		}
+
+		value, err := decoder.Decode()
+
+		if err != nil {
+			return err
+		}
+
+		elements = append(elements, KeyValueElement{Key: key, Value: value})
+	}
+
+	return dic.replace(elements)
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// Package datatypes/dictionary provides an easy dictionary (key => value) homogeneous
+// struct management, making the iteration of a unique-key lists more powerful,
+// simple and clean, accepting primitives types and complex user structs as well.
+
+// This part of package contains the tests for the binary encoding and decoding
+
+package dictionary
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+
+	"github.com/jaimelopez/datatypes/internal/codec"
+	"github.com/stretchr/testify/assert"
+)
+
+type binaryTestStruct struct {
+	Name string
+}
+
+type binaryTestID int
+
+func init() {
+	gob.Register(binaryTestStruct{})
+}
+
+func TestGobEncoding(test *testing.T) {
+	dictionary, _ := NewDictionary([]KeyValueElement{{Key: 1, Value: binaryTestStruct{"one"}}, {Key: 2, Value: binaryTestStruct{"two"}}})
+
+	var buffer bytes.Buffer
+
+	assert.Nil(test, gob.NewEncoder(&buffer).Encode(dictionary), "Unexpected error on gob encoding")
+
+	decoded := NewEmptyDictionary()
+
+	assert.Nil(test, gob.NewDecoder(&buffer).Decode(decoded), "Unexpected error on gob decoding")
+	assert.Equal(test, dictionary.Elements(), decoded.Elements(), "Wrong round trip on gob encoding")
+	assert.Equal(test, dictionary.valueDefinition, decoded.valueDefinition, "Definitions should be preserved on gob encoding")
+
+	buffer.Reset()
+	gob.NewEncoder(&buffer).Encode([]KeyValueElement{{Key: []int{1}, Value: 1}})
+
+	assert.Equal(test, ErrInvalidKeyValueElementType, decoded.GobDecode(buffer.Bytes()), "Non-hashable keys should return an error on gob decoding")
+	assert.Equal(test, dictionary.Elements(), decoded.Elements(), "Failed decodings should leave the dictionary untouched")
+}
+
+func TestBinaryMarshaling(test *testing.T) {
+	dictionary, _ := NewDictionary([]KeyValueElement{{Key: "1Key", Value: 1.5}, {Key: "2Key", Value: -2.0}})
+
+	data, err := dictionary.MarshalBinary()
+
+	assert.Nil(test, err, "Unexpected error on binary marshaling")
+
+	decoded := NewEmptyDictionary()
+
+	assert.Nil(test, decoded.UnmarshalBinary(data), "Unexpected error on binary unmarshaling")
+	assert.Equal(test, dictionary.Elements(), decoded.Elements(), "Wrong round trip on binary marshaling")
+
+	empty, _ := NewEmptyDictionary().MarshalBinary()
+
+	assert.Nil(test, decoded.UnmarshalBinary(empty), "Unexpected error unmarshaling an empty dictionary")
+	assert.True(test, decoded.IsEmpty(), "Wrong round trip of an empty dictionary")
+
+	structs, _ := NewDictionary([]KeyValueElement{{Key: 1, Value: binaryTestStruct{"one"}}})
+	_, err = structs.MarshalBinary()
+
+	assert.Equal(test, ErrUnsupportedType, err, "Non primitive values should return an error on binary marshaling")
+}
+
+func TestBinaryMarshalingIsDeterministic(test *testing.T) {
+	first, _ := NewDictionary([]KeyValueElement{{Key: 3, Value: "c"}, {Key: 1, Value: "a"}, {Key: 2, Value: "b"}})
+	second, _ := NewDictionary([]KeyValueElement{{Key: 2, Value: "b"}, {Key: 3, Value: "c"}, {Key: 1, Value: "a"}})
+	booleans, _ := NewDictionary([]KeyValueElement{{Key: true, Value: 1}, {Key: false, Value: 0}})
+
+	expected, _ := first.MarshalBinary()
+	expectedBooleans, _ := booleans.MarshalBinary()
+
+	for attempt := 0; attempt < 20; attempt++ {
+		encoded, _ := second.MarshalBinary()
+		encodedBooleans, _ := booleans.MarshalBinary()
+
+		assert.Equal(test, expected, encoded, "Equal dictionaries should always be encoded the same way")
+		assert.Equal(test, expectedBooleans, encodedBooleans, "Non-ordered keys should always be encoded the same way")
+	}
+}
+
+func TestBinaryMarshalingOfNamedTypes(test *testing.T) {
+	dictionary, _ := NewDictionary([]KeyValueElement{{Key: binaryTestID(1), Value: "one"}})
+
+	data, _ := dictionary.MarshalBinary()
+
+	assert.Equal(test, ErrUnsupportedType, NewEmptyDictionary().UnmarshalBinary(data), "Named types should be registered to be unmarshaled")
+
+	decoded := NewEmptyDictionary().DecodeAs(binaryTestID(0), nil)
+
+	assert.Nil(test, decoded.UnmarshalBinary(data), "Unexpected error unmarshaling a registered type")
+	assert.Equal(test, dictionary.Elements(), decoded.Elements(), "Wrong round trip of a registered type")
+}
+
+func TestBinaryUnmarshalingOfCorruptedData(test *testing.T) {
+	dictionary, _ := NewDictionary([]KeyValueElement{{Key: "1Key", Value: 1}})
+	data, _ := dictionary.MarshalBinary()
+
+	assert.Equal(test, ErrCorruptedData, dictionary.UnmarshalBinary(data[:len(data)-1]), "Truncated data should return an error")
+	assert.Equal(test, KeyValueMap{"1Key": 1}, *dictionary.Elements(), "Failed decodings should leave the dictionary untouched")
+}
+
+func FuzzUnmarshalBinary(fuzz *testing.F) {
+	valid, _ := NewDictionary([]KeyValueElement{{Key: "1Key", Value: 1}, {Key: "2Key", Value: 2}})
+	data, _ := valid.MarshalBinary()
+	fuzz.Add(data)
+
+	fuzz.Fuzz(func(test *testing.T, data []byte) {
+		dictionary := NewEmptyDictionary()
+
+		// The checksum is recomputed so the fuzzed payload reaches the decoding
+		if dictionary.UnmarshalBinary(codec.Seal(data)) != nil {
+			return
+		}
+
+		// Any accepted data must be a valid dictionary which can be encoded again
+		encoded, err := dictionary.MarshalBinary()
+
+		assert.Nil(test, err, "Accepted data should be encoded again")
+		assert.Nil(test, NewEmptyDictionary().UnmarshalBinary(encoded), "Encoded data should be accepted again")
+	})
+}
@@ -10,7 +10,11 @@
 
 package dictionary
 
-import "reflect"
+import (
+	"reflect"
+
+	"github.com/jaimelopez/datatypes/internal/observer"
+)
 
 // KeyElement represents Key in Key-Value object
 type KeyElement interface{}
@@ -32,6 +36,9 @@ type Dictionary struct {
 	keyDefinition   reflect.Type
 	valueDefinition reflect.Type
 	elements        KeyValueMap
+	keyDecoding     reflect.Type
+	valueDecoding   reflect.Type
+	observers       *observer.Hub[Event]
 }
 
 // Add a key-value element to the dictionary
@@ -52,6 +59,7 @@ func (dic *Dictionary) Add(key KeyElement, value ValueElement) error {
 	}
 
 	dic.elements[key] = value
+	dic.notify(Event{Type: AddEvent, Key: key, New: value})
 
 	return nil
 }
@@ -62,12 +70,18 @@ func (dic *Dictionary) AddKeyValueElement(element KeyValueElement) error {
 }
 
 // AddRange inserts a range (slice) of KeyValueElement inside the dictionary
-// If the parameter can't be converted to a iterable data type it's return an error
-func (dic *Dictionary) AddRange(elements []KeyValueElement) error {
+// The range is added all or nothing, so if any element can't be added
+// the dictionary is left untouched and a TypeMismatchError or a DuplicateError
+// is returned, or all of them joined if the JoinErrors option is specified
+func (dic *Dictionary) AddRange(elements []KeyValueElement, options ...RangeOption) error {
+	if err := dic.checkAdditions(elements, options); err != nil {
+		return err
+	}
+
+	defer dic.batch()()
+
 	for _, element := range elements {
-		if err := dic.AddKeyValueElement(element); err != nil {
-			return err
-		}
+		dic.AddKeyValueElement(element)
 	}
 
 	return nil
@@ -113,11 +127,14 @@ func (dic *Dictionary) Values() []ValueElement {
 	return values
 }
 
-// Extract the first element and return it
+// Extract an element and return it
+// Since the elements are not sorted, the extracted element could be any of them.
+// Use an OrderedDictionary to extract them in insertion order
 // Keep in mind that this method will modify the dictionary elements subtracting that element
 func (dic *Dictionary) Extract() *KeyValueElement {
 	for key, value := range dic.elements {
-		dic.Delete(key)
+		delete(dic.elements, key)
+		dic.notify(Event{Type: ExtractEvent, Key: key, Old: value})
 
 		return &KeyValueElement{key, value}
 	}
@@ -130,7 +147,8 @@ func (dic *Dictionary) Extract() *KeyValueElement {
 func (dic *Dictionary) ExtractKey(key KeyElement) (*KeyValueElement, error) {
 	if dic.Contains(key) {
 		element := &KeyValueElement{key, dic.elements[key]}
-		dic.Delete(key)
+		delete(dic.elements, key)
+		dic.notify(Event{Type: ExtractEvent, Key: key, Old: element.Value})
 
 		return element, nil
 	}
@@ -148,7 +166,9 @@ func (dic *Dictionary) Set(key KeyElement, value ValueElement) error {
 		return ErrElementNotFound
 	}
 
+	old := dic.elements[key]
 	dic.elements[key] = value
+	dic.notify(Event{Type: SetEvent, Key: key, Old: old, New: value})
 
 	return nil
 }
@@ -160,7 +180,9 @@ func (dic *Dictionary) Delete(key KeyElement) error {
 		return ErrElementNotFound
 	}
 
+	old := dic.elements[key]
 	delete(dic.elements, key)
+	dic.notify(Event{Type: DeleteEvent, Key: key, Old: old})
 
 	return nil
 }
@@ -216,6 +238,72 @@ func (dic *Dictionary) isHomogeneousWith(key KeyElement, value ValueElement) boo
 		dic.valueDefinition == reflect.TypeOf(value)
 }
 
+// checkAdditions checks if all the elements could be added to the dictionary
+// returning the failures the Add calls would find
+func (dic *Dictionary) checkAdditions(elements []KeyValueElement, options []RangeOption) error {
+	if len(elements) == 0 {
+		return nil
+	}
+
+	keyDefinition, valueDefinition := dic.keyDefinition, dic.valueDefinition
+
+	if dic.IsEmpty() {
+		keyDefinition, valueDefinition = reflect.TypeOf(elements[0].Key), reflect.TypeOf(elements[0].Value)
+	}
+
+	staged := make(map[KeyElement]bool, len(elements))
+
+	return checkElements(elements, keyDefinition, valueDefinition, func(position int, element KeyValueElement) error {
+		if dic.Contains(element.Key) || staged[element.Key] {
+			return &DuplicateError{Key: element.Key, Index: position}
+		}
+
+		staged[element.Key] = true
+
+		return nil
+	}, options)
+}
+
+// clone returns a copy of the dictionary which doesn't share its storage with the original
+func (dic *Dictionary) clone() *Dictionary {
+	dictionary := NewEmptyDictionary()
+	dictionary.keyDefinition = dic.keyDefinition
+	dictionary.valueDefinition = dic.valueDefinition
+	dictionary.keyDecoding = dic.keyDecoding
+	dictionary.valueDecoding = dic.valueDecoding
+
+	for key, value := range dic.elements {
+		dictionary.elements[key] = value
+	}
+
+	return dictionary
+}
+
+// replace stores the decoded elements in the dictionary if they're unique and homogeneous
+// The replacement is notified as a single ResetEvent
+func (dic *Dictionary) replace(elements []KeyValueElement) error {
+	decoded := NewEmptyDictionary()
+	decoded.keyDecoding = dic.keyDecoding
+	decoded.valueDecoding = dic.valueDecoding
+
+	for _, element := range elements {
+		// Keys which can't be used as map keys, like decoded slices or maps, would panic
+		if element.Key != nil && !reflect.TypeOf(element.Key).Comparable() {
+			return ErrInvalidKeyValueElementType
+		}
+
+		if err := decoded.AddKeyValueElement(element); err != nil {
+			return err
+		}
+	}
+
+	decoded.observers = dic.observers
+	*dic = *decoded
+	dic.notify(Event{Type: ResetEvent})
+
+	return nil
+}
+
 // NewEmptyDictionary instances a new empty dictionary
 func NewEmptyDictionary() *Dictionary {
 	dic := new(Dictionary)
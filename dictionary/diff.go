@@ -0,0 +1,149 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// Package datatypes/dictionary provides an easy dictionary (key => value) homogeneous
+// struct management, making the iteration of a unique-key lists more powerful,
+// simple and clean, accepting primitives types and complex user structs as well.
+
+// This part of package contains the differences between dictionaries
+
+package dictionary
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/jaimelopez/datatypes/generic"
+)
+
+// Change represents a key whose value is different between two dictionaries
+type Change struct {
+	Key KeyElement
+	Old ValueElement
+	New ValueElement
+}
+
+// Patch represents the differences between two dictionaries
+// Entries are sorted by key when the keys have a natural order
+type Patch struct {
+	Added   []KeyValueElement
+	Removed []KeyValueElement
+	Changed []Change
+}
+
+// IsEmpty checks if the patch has no differences
+func (patch *Patch) IsEmpty() bool {
+	return len(patch.Added) == 0 && len(patch.Removed) == 0 && len(patch.Changed) == 0
+}
+
+// Apply transforms the dictionary applying the specified patch atomically
+// The added keys must not exist and the removed and changed ones must exist
+// with their old values, otherwise it returns ErrPatchConflict and the
+// dictionary is left untouched
+func (dic *Dictionary) Apply(patch *Patch) error {
+	if !dic.satisfies(patch) {
+		return ErrPatchConflict
+	}
+
+	tx := dic.Begin()
+
+	for _, element := range patch.Removed {
+		tx.Delete(element.Key)
+	}
+
+	for _, change := range patch.Changed {
+		tx.Set(change.Key, change.New)
+	}
+
+	for _, element := range patch.Added {
+		tx.Add(element.Key, element.Value)
+	}
+
+	return tx.Commit()
+}
+
+// satisfies checks if the preconditions of the patch hold in the dictionary
+func (dic *Dictionary) satisfies(patch *Patch) bool {
+	for _, element := range patch.Removed {
+		if !dic.hasValue(element.Key, element.Value) {
+			return false
+		}
+	}
+
+	for _, change := range patch.Changed {
+		if !dic.hasValue(change.Key, change.Old) {
+			return false
+		}
+	}
+
+	for _, element := range patch.Added {
+		if dic.Contains(element.Key) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// hasValue checks if the key exists with a value deeply equal to the specified one
+func (dic *Dictionary) hasValue(key KeyElement, value ValueElement) bool {
+	current, exists := dic.elements[key]
+
+	return exists && reflect.DeepEqual(current, value)
+}
+
+// Diff returns the patch which transforms the first dictionary into the second one
+// Values are compared with reflect.DeepEqual as ContainsValue does. Both
+// dictionaries must have the same key and value types unless any of them is empty
+func Diff(from *Dictionary, to *Dictionary) (*Patch, error) {
+	if !from.IsEmpty() && !to.IsEmpty() &&
+		(from.keyDefinition != to.keyDefinition || from.valueDefinition != to.valueDefinition) {
+		return nil, ErrInvalidKeyValueElementType
+	}
+
+	patch := new(Patch)
+
+	for key, old := range from.elements {
+		value, exists := to.elements[key]
+
+		switch {
+		case !exists:
+			patch.Removed = append(patch.Removed, KeyValueElement{Key: key, Value: old})
+		case !reflect.DeepEqual(old, value):
+			patch.Changed = append(patch.Changed, Change{Key: key, Old: old, New: value})
+		}
+	}
+
+	for key, value := range to.elements {
+		if _, exists := from.elements[key]; !exists {
+			patch.Added = append(patch.Added, KeyValueElement{Key: key, Value: value})
+		}
+	}
+
+	definition := from.keyDefinition
+
+	if from.IsEmpty() {
+		definition = to.keyDefinition
+	}
+
+	if generic.IsOrdered(definition) {
+		patch.sort()
+	}
+
+	return patch, nil
+}
+
+func (patch *Patch) sort() {
+	sort.Slice(patch.Added, func(first, second int) bool {
+		return generic.Compare(patch.Added[first].Key, patch.Added[second].Key) < 0
+	})
+
+	sort.Slice(patch.Removed, func(first, second int) bool {
+		return generic.Compare(patch.Removed[first].Key, patch.Removed[second].Key) < 0
+	})
+
+	sort.Slice(patch.Changed, func(first, second int) bool {
+		return generic.Compare(patch.Changed[first].Key, patch.Changed[second].Key) < 0
+	})
+}
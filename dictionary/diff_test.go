@@ -0,0 +1,71 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// Package datatypes/dictionary provides an easy dictionary (key => value) homogeneous
+// struct management, making the iteration of a unique-key lists more powerful,
+// simple and clean, accepting primitives types and complex user structs as well.
+
+// This part of package contains the tests for the differences between dictionaries
+
+package dictionary
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffFunction(test *testing.T) {
+	from, _ := NewDictionary([]KeyValueElement{{Key: "a", Value: []int{1}}, {Key: "b", Value: []int{2}}, {Key: "c", Value: []int{3}}})
+	to, _ := NewDictionary([]KeyValueElement{{Key: "a", Value: []int{1}}, {Key: "b", Value: []int{20}}, {Key: "d", Value: []int{4}}})
+
+	patch, err := Diff(from, to)
+
+	assert.Nil(test, err, "Unexpected error on Diff function")
+	assert.Equal(test, &Patch{
+		Added:   []KeyValueElement{{Key: "d", Value: []int{4}}},
+		Removed: []KeyValueElement{{Key: "c", Value: []int{3}}},
+		Changed: []Change{{Key: "b", Old: []int{2}, New: []int{20}}},
+	}, patch, "Wrong differences on Diff function")
+
+	same, _ := Diff(from, from)
+
+	assert.True(test, same.IsEmpty(), "Equal dictionaries shouldn't have differences")
+
+	numbers, _ := NewDictionary([]KeyValueElement{{Key: 1, Value: 1}})
+	_, err = Diff(from, numbers)
+
+	assert.Equal(test, ErrInvalidKeyValueElementType, err, "Dictionaries of different types should return an error")
+
+	all, _ := Diff(NewEmptyDictionary(), numbers)
+
+	assert.Equal(test, []KeyValueElement{{Key: 1, Value: 1}}, all.Added, "Diffing from an empty dictionary should add every element")
+}
+
+func TestApplyMethod(test *testing.T) {
+	from, _ := NewDictionary([]KeyValueElement{{Key: "a", Value: 1}, {Key: "b", Value: 2}, {Key: "c", Value: 3}})
+	to, _ := NewDictionary([]KeyValueElement{{Key: "a", Value: 1}, {Key: "b", Value: 20}, {Key: "d", Value: 4}})
+
+	patch, _ := Diff(from, to)
+
+	assert.Nil(test, from.Apply(patch), "Unexpected error on Apply method")
+	assert.Equal(test, to.Elements(), from.Elements(), "Applying a diff should transform the dictionary into the other one")
+	assert.Equal(test, ErrPatchConflict, from.Apply(patch), "Applying a patch twice should return a conflict")
+	assert.Equal(test, to.Elements(), from.Elements(), "Conflicting patches should leave the dictionary untouched")
+}
+
+func TestApplyConflicts(test *testing.T) {
+	dictionary, _ := NewDictionary([]KeyValueElement{{Key: "a", Value: 1}})
+
+	for _, patch := range []*Patch{
+		{Added: []KeyValueElement{{Key: "a", Value: 1}}},
+		{Removed: []KeyValueElement{{Key: "a", Value: 2}}},
+		{Removed: []KeyValueElement{{Key: "b", Value: 1}}},
+		{Changed: []Change{{Key: "a", Old: 2, New: 3}}},
+	} {
+		assert.Equal(test, ErrPatchConflict, dictionary.Apply(patch), "Unsatisfied preconditions should return a conflict")
+	}
+
+	assert.Equal(test, KeyValueMap{"a": 1}, *dictionary.Elements(), "Conflicting patches should leave the dictionary untouched")
+}
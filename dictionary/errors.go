@@ -10,16 +10,148 @@
 
 package dictionary
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/jaimelopez/datatypes/internal/codec"
+)
 
 // ErrInvalidKeyValueElementType represents an error for invalid key-value type
 var ErrInvalidKeyValueElementType = errors.New("Invalid key-value element type: dictionary must be homogeneous")
 
+// ErrInvalidKeyType represents an error for keys which can't be compared, like slices or maps
+var ErrInvalidKeyType = errors.New("Invalid key type: keys must be comparable")
+
 // ErrDuplicatedKey represents an error for duplicated entries
 var ErrDuplicatedKey = errors.New("Duplicated key in dictionary")
 
+// ErrDuplicatedValue represents an error for duplicated values in bidirectional dictionaries
+var ErrDuplicatedValue = errors.New("Duplicated value in bidirectional dictionary")
+
+// ErrDuplicatedEntry represents an error for duplicated key-value entries in multi dictionaries
+var ErrDuplicatedEntry = errors.New("Duplicated key-value entry in multi dictionary")
+
 // ErrEmptyDictionary represents an error for non-iterable dictionaries
 var ErrEmptyDictionary = errors.New("Empty dictionary can not be iterable")
 
 // ErrElementNotFound represents an error for non-found element
 var ErrElementNotFound = errors.New("Element not found")
+
+// ErrIndexOutOfRange represents an error for positions out of the dictionary bounds
+var ErrIndexOutOfRange = errors.New("Index out of range")
+
+// ErrNonOrderedKey represents an error for keys which can't be sorted without a comparator
+var ErrNonOrderedKey = errors.New("Non-ordered key type: a comparator must be specified")
+
+// ErrInvalidScanSource represents an error for database values which can't be scanned into a dictionary
+var ErrInvalidScanSource = errors.New("Invalid database value: it must be a JSON object or an array of key-value pairs")
+
+// ErrTransactionClosed represents an error for transactions already committed or rolled back
+var ErrTransactionClosed = errors.New("Transaction has already been committed or rolled back")
+
+// ErrNothingToUndo represents an error for undoing without recorded changes
+var ErrNothingToUndo = errors.New("Nothing to undo")
+
+// ErrNothingToRedo represents an error for redoing without undone changes
+var ErrNothingToRedo = errors.New("Nothing to redo")
+
+// ErrVersionNotFound represents an error for non-existing or no longer retained versions
+var ErrVersionNotFound = errors.New("Version not found")
+
+// ErrPatchConflict represents an error for patches whose preconditions don't hold
+var ErrPatchConflict = errors.New("Patch conflict: the dictionary doesn't match the patch preconditions")
+
+// ErrInvalidJSONPatch represents an error for malformed or unsupported JSON Patch documents
+var ErrInvalidJSONPatch = errors.New("Invalid JSON Patch document")
+
+// ErrNonTextKey represents an error for keys which can't be represented as text
+var ErrNonTextKey = errors.New("Non-text key: keys must be strings or implement encoding.TextMarshaler")
+
+// ErrCorruptedData represents an error for malformed, truncated or tampered binary data
+var ErrCorruptedData = codec.ErrCorrupted
+
+// ErrUnsupportedVersion represents an error for binary data written by an unknown version of the format
+var ErrUnsupportedVersion = codec.ErrUnsupportedVersion
+
+// ErrUnsupportedType represents an error for key or value types which the binary format can't represent
+var ErrUnsupportedType = codec.ErrUnsupportedType
+
+// TypeMismatchError represents an error for an element of a range whose key
+// or value type doesn't match the types of the dictionary
+// Expected and Actual refer to the key types when IsKey is true, otherwise to the value ones.
+// It's considered equal to ErrInvalidKeyValueElementType by errors.Is
+type TypeMismatchError struct {
+	Expected reflect.Type
+	Actual   reflect.Type
+	IsKey    bool
+	Index    int
+}
+
+func (err *TypeMismatchError) Error() string {
+	part := "value"
+
+	if err.IsKey {
+		part = "key"
+	}
+
+	return fmt.Sprintf("Element at index %d: %s: expected %s %v but got %v", err.Index, ErrInvalidKeyValueElementType, part, err.Expected, err.Actual)
+}
+
+// Is reports if the target is ErrInvalidKeyValueElementType
+func (err *TypeMismatchError) Is(target error) bool {
+	return target == ErrInvalidKeyValueElementType
+}
+
+// DuplicateError represents an error for an element of a range whose key is
+// already stored in the dictionary or repeated in the range itself
+// It's considered equal to ErrDuplicatedKey by errors.Is
+type DuplicateError struct {
+	Key   KeyElement
+	Index int
+}
+
+func (err *DuplicateError) Error() string {
+	return fmt.Sprintf("Element at index %d: %s: %v", err.Index, ErrDuplicatedKey, err.Key)
+}
+
+// Is reports if the target is ErrDuplicatedKey
+func (err *DuplicateError) Is(target error) bool {
+	return target == ErrDuplicatedKey
+}
+
+// DuplicateValueError represents an error for an element of a range whose value is
+// already stored in a bidirectional dictionary or repeated in the range itself
+// It's considered equal to ErrDuplicatedValue by errors.Is
+type DuplicateValueError struct {
+	Value ValueElement
+	Index int
+}
+
+func (err *DuplicateValueError) Error() string {
+	return fmt.Sprintf("Element at index %d: %s: %v", err.Index, ErrDuplicatedValue, err.Value)
+}
+
+// Is reports if the target is ErrDuplicatedValue
+func (err *DuplicateValueError) Is(target error) bool {
+	return target == ErrDuplicatedValue
+}
+
+// DuplicateEntryError represents an error for a value of a range which is already
+// stored for the key in a multi dictionary or repeated in the range itself
+// It's considered equal to ErrDuplicatedEntry by errors.Is
+type DuplicateEntryError struct {
+	Key   KeyElement
+	Value ValueElement
+	Index int
+}
+
+func (err *DuplicateEntryError) Error() string {
+	return fmt.Sprintf("Element at index %d: %s: %v => %v", err.Index, ErrDuplicatedEntry, err.Key, err.Value)
+}
+
+// Is reports if the target is ErrDuplicatedEntry
+func (err *DuplicateEntryError) Is(target error) bool {
+	return target == ErrDuplicatedEntry
+}
@@ -0,0 +1,35 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// Package datatypes/dictionary provides an easy dictionary (key => value) homogeneous
+// struct management, making the iteration of a unique-key lists more powerful,
+// simple and clean, accepting primitives types and complex user structs as well.
+
+// This part of package contains the grouping of collections into dictionaries
+
+package dictionary
+
+import "github.com/jaimelopez/datatypes/collection"
+
+// GroupBy groups the elements of the collection by the key returned by the specified function
+// It returns a dictionary whose values are collections with the elements of every key,
+// keeping the order they have in the original collection
+// If the returned keys are not homogeneous it returns an error
+func GroupBy(elements *collection.Collection, f func(collection.Element) KeyElement) (*Dictionary, error) {
+	dictionary := NewEmptyDictionary()
+
+	for _, element := range elements.Elements() {
+		key := f(element)
+
+		if !dictionary.Contains(key) {
+			if err := dictionary.Add(key, collection.NewEmptyCollection()); err != nil {
+				return nil, err
+			}
+		}
+
+		dictionary.elements[key].(*collection.Collection).Add(element)
+	}
+
+	return dictionary, nil
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// Package datatypes/dictionary provides an easy dictionary (key => value) homogeneous
+// struct management, making the iteration of a unique-key lists more powerful,
+// simple and clean, accepting primitives types and complex user structs as well.
+
+// This part of package contains the tests for the grouping of collections
+
+package dictionary
+
+import (
+	"testing"
+
+	"github.com/jaimelopez/datatypes/collection"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupByMethod(test *testing.T) {
+	elements := collection.NewCollection([]string{"apple", "banana", "avocado"})
+
+	groups, err := GroupBy(elements, func(element collection.Element) KeyElement {
+		return element.(string)[:1]
+	})
+
+	assert.Nil(test, err, "Unexpected error on GroupBy method")
+	assert.Equal(test, 2, groups.Size(), "Wrong number of groups on GroupBy method")
+
+	group, _ := groups.Element("a")
+
+	assert.Equal(test, []collection.Element{"apple", "avocado"}, (*group).(*collection.Collection).Elements(), "Wrong grouped elements on GroupBy method")
+
+	_, err = GroupBy(elements, func(element collection.Element) KeyElement {
+		if element == "apple" {
+			return 1
+		}
+
+		return "key"
+	})
+
+	assert.Equal(test, ErrInvalidKeyValueElementType, err, "Non-homogeneous keys should return an error on GroupBy method")
+}
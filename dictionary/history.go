@@ -0,0 +1,258 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// Package datatypes/dictionary provides an easy dictionary (key => value) homogeneous
+// struct management, making the iteration of a unique-key lists more powerful,
+// simple and clean, accepting primitives types and complex user structs as well.
+
+// This part of package contains the undo/redo history of dictionaries
+
+package dictionary
+
+// History records the changes of a dictionary so they can be undone and redone
+// and keeps versioned snapshots of its past states
+// Every notified change is a step, so a range operation or a committed
+// transaction is undone at once. The state of the dictionary is mirrored in
+// a persistent dictionary, so taking snapshots is done in constant time
+type History struct {
+	dictionary   *Dictionary
+	subscription *Subscription
+	limit        int
+	undo         [][]Event
+	redo         [][]Event
+	applying     bool
+	disabled     bool
+	mirror       *PersistentDictionary
+	versions     map[int]*PersistentDictionary
+	order        []int
+	version      int
+}
+
+// Undo reverts the last recorded change of the dictionary
+// If there are no recorded changes it returns ErrNothingToUndo
+func (history *History) Undo() error {
+	if len(history.undo) == 0 {
+		return ErrNothingToUndo
+	}
+
+	step := history.undo[len(history.undo)-1]
+	changes := make([]Event, 0, len(step))
+
+	for position := len(step) - 1; position >= 0; position-- {
+		event := step[position]
+
+		switch event.Type {
+		case AddEvent:
+			changes = append(changes, Event{Type: DeleteEvent, Key: event.Key, Old: event.New})
+		case SetEvent:
+			changes = append(changes, Event{Type: SetEvent, Key: event.Key, Old: event.New, New: event.Old})
+		default:
+			changes = append(changes, Event{Type: AddEvent, Key: event.Key, New: event.Old})
+		}
+	}
+
+	if err := history.apply(changes); err != nil {
+		return err
+	}
+
+	history.undo = history.undo[:len(history.undo)-1]
+	history.redo = append(history.redo, step)
+
+	return nil
+}
+
+// Redo applies again the last undone change of the dictionary
+// If there are no undone changes it returns ErrNothingToRedo
+func (history *History) Redo() error {
+	if len(history.redo) == 0 {
+		return ErrNothingToRedo
+	}
+
+	step := history.redo[len(history.redo)-1]
+
+	if err := history.apply(step); err != nil {
+		return err
+	}
+
+	history.redo = history.redo[:len(history.redo)-1]
+	history.undo = append(history.undo, step)
+
+	return nil
+}
+
+// CanUndo checks if there are recorded changes to undo
+func (history *History) CanUndo() bool {
+	return len(history.undo) > 0
+}
+
+// CanRedo checks if there are undone changes to redo
+func (history *History) CanRedo() bool {
+	return len(history.redo) > 0
+}
+
+// Snapshot keeps the current state of the dictionary and returns its version id
+func (history *History) Snapshot() int {
+	history.version++
+	history.versions[history.version] = history.mirror
+	history.order = append(history.order, history.version)
+
+	if history.limit > 0 && len(history.order) > history.limit {
+		delete(history.versions, history.order[0])
+		history.order = history.order[1:]
+	}
+
+	return history.version
+}
+
+// At returns a read-only view of the dictionary in the specified version
+// If the version doesn't exist or it's not retained anymore it returns ErrVersionNotFound
+func (history *History) At(version int) (*PersistentDictionary, error) {
+	snapshot, exists := history.versions[version]
+
+	if !exists {
+		return nil, ErrVersionNotFound
+	}
+
+	return snapshot, nil
+}
+
+// Disable stops recording the changes of the dictionary
+// The changes recorded before can still be undone and redone
+func (history *History) Disable() {
+	history.subscription.Unsubscribe()
+	history.disabled = true
+}
+
+// apply checks the changes against the mirror and, if all of them can be done,
+// applies them to the dictionary at once without recording them as a new step
+// Extractions are applied as deletions
+func (history *History) apply(changes []Event) error {
+	if history.disabled {
+		// The mirror doesn't follow the dictionary anymore, so it's rebuilt to check the changes
+		history.mirror = history.dictionary.Persistent()
+	}
+
+	if err := history.check(changes); err != nil {
+		return err
+	}
+
+	history.applying = true
+	defer func() { history.applying = false }()
+	defer history.dictionary.batch()()
+
+	for _, change := range changes {
+		switch change.Type {
+		case AddEvent:
+			history.dictionary.Add(change.Key, change.New)
+		case SetEvent:
+			history.dictionary.Set(change.Key, change.New)
+		default:
+			history.dictionary.Delete(change.Key)
+		}
+	}
+
+	return nil
+}
+
+// check verifies the changes can be applied in order over the mirror,
+// which has the same elements than the dictionary, without modifying it
+func (history *History) check(changes []Event) error {
+	mirror := history.mirror
+
+	for _, change := range changes {
+		var err error
+
+		switch exists := mirror.Contains(change.Key); {
+		case change.Type == AddEvent && exists:
+			return ErrDuplicatedKey
+		case change.Type != AddEvent && !exists:
+			return ErrElementNotFound
+		case change.Type == AddEvent || change.Type == SetEvent:
+			mirror, err = mirror.With(change.Key, change.New)
+		default:
+			mirror = mirror.Without(change.Key)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (history *History) record(events []Event) {
+	for _, event := range events {
+		if event.Type == ResetEvent {
+			history.reset()
+
+			return
+		}
+
+		if !history.mirrorEvent(event) {
+			// The mirror can't follow the change, so it's rebuilt from
+			// the dictionary which already has all the events applied
+			history.mirror = history.dictionary.Persistent()
+
+			break
+		}
+	}
+
+	if history.applying {
+		return
+	}
+
+	history.undo = append(history.undo, events)
+	history.redo = nil
+
+	if history.limit > 0 && len(history.undo) > history.limit {
+		history.undo = history.undo[1:]
+	}
+}
+
+// mirrorEvent applies the event to the mirror returning false if it can't be applied
+func (history *History) mirrorEvent(event Event) bool {
+	if event.Type == AddEvent || event.Type == SetEvent {
+		mirror, err := history.mirror.With(event.Key, event.New)
+
+		if err != nil {
+			return false
+		}
+
+		history.mirror = mirror
+
+		return true
+	}
+
+	history.mirror = history.mirror.Without(event.Key)
+
+	return true
+}
+
+// reset discards the recorded changes since they can't be undone after
+// all the elements have been replaced, and mirrors the new state
+func (history *History) reset() {
+	history.mirror = history.dictionary.Persistent()
+	history.undo = nil
+	history.redo = nil
+}
+
+// EnableHistory starts recording the changes of the dictionary
+// The limit bounds both the number of changes which can be undone and the
+// number of retained snapshots, being unlimited when it isn't positive.
+// Decoding methods like UnmarshalJSON replace all the elements at once, so
+// they can't be undone and the recorded changes are discarded, although the
+// snapshots taken before are kept
+func (dic *Dictionary) EnableHistory(limit int) *History {
+	history := &History{
+		dictionary: dic,
+		limit:      limit,
+		mirror:     dic.Persistent(),
+		versions:   make(map[int]*PersistentDictionary),
+	}
+
+	history.subscription = dic.Subscribe(history.record)
+
+	return history
+}
@@ -0,0 +1,179 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// Package datatypes/dictionary provides an easy dictionary (key => value) homogeneous
+// struct management, making the iteration of a unique-key lists more powerful,
+// simple and clean, accepting primitives types and complex user structs as well.
+
+// This part of package contains the tests for the undo/redo history
+
+package dictionary
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUndoAndRedoMethods(test *testing.T) {
+	dictionary, _ := NewDictionary([]KeyValueElement{{Key: "1Key", Value: 1}, {Key: "2Key", Value: 2}})
+	history := dictionary.EnableHistory(0)
+
+	dictionary.Add("3Key", 3)
+	dictionary.Set("1Key", 10)
+	dictionary.Delete("2Key")
+	dictionary.ExtractKey("3Key")
+
+	assert.Equal(test, KeyValueMap{"1Key": 10}, *dictionary.Elements(), "Wrong elements before undoing")
+
+	for _, expected := range []KeyValueMap{
+		{"1Key": 10, "3Key": 3},
+		{"1Key": 10, "2Key": 2, "3Key": 3},
+		{"1Key": 1, "2Key": 2, "3Key": 3},
+		{"1Key": 1, "2Key": 2},
+	} {
+		assert.Nil(test, history.Undo(), "Unexpected error on Undo method")
+		assert.Equal(test, expected, *dictionary.Elements(), "Wrong elements after undoing")
+	}
+
+	assert.Equal(test, ErrNothingToUndo, history.Undo(), "Undoing without changes should return an error")
+	assert.False(test, history.CanUndo(), "Wrong result on CanUndo method")
+
+	assert.Nil(test, history.Redo(), "Unexpected error on Redo method")
+	assert.Nil(test, history.Redo(), "Unexpected error on Redo method")
+	assert.Equal(test, KeyValueMap{"1Key": 10, "2Key": 2, "3Key": 3}, *dictionary.Elements(), "Wrong elements after redoing")
+	assert.True(test, history.CanRedo(), "Wrong result on CanRedo method")
+
+	dictionary.Add("4Key", 4)
+
+	assert.Equal(test, ErrNothingToRedo, history.Redo(), "New changes should discard the undone ones")
+}
+
+func TestUndoConflictingChanges(test *testing.T) {
+	dictionary, _ := NewDictionary([]KeyValueElement{{Key: "1Key", Value: 1}})
+	history := dictionary.EnableHistory(0)
+	notified := 0
+
+	dictionary.AddRange([]KeyValueElement{{Key: "2Key", Value: 2}, {Key: "3Key", Value: 3}})
+	dictionary.Delete("1Key")
+	history.Disable()
+	dictionary.Add("1Key", 100)
+	dictionary.Subscribe(func([]Event) { notified++ })
+
+	assert.Equal(test, ErrDuplicatedKey, history.Undo(), "Undoing conflicting changes should return an error")
+	assert.Equal(test, KeyValueMap{"1Key": 100, "2Key": 2, "3Key": 3}, *dictionary.Elements(), "Failed undoings should leave the dictionary untouched")
+
+	dictionary.Delete("1Key")
+	notified = 0
+
+	assert.Nil(test, history.Undo(), "Unexpected error undoing after solving the conflict")
+	assert.Nil(test, history.Undo(), "Unexpected error undoing a range operation")
+	assert.Equal(test, KeyValueMap{"1Key": 1}, *dictionary.Elements(), "Wrong elements after undoing")
+	assert.Equal(test, 2, notified, "Every undone step should be notified at once")
+}
+
+func TestUndoRangeOperations(test *testing.T) {
+	dictionary := NewEmptyDictionary()
+	history := dictionary.EnableHistory(0)
+
+	dictionary.AddRange([]KeyValueElement{{Key: "1Key", Value: 1}, {Key: "2Key", Value: 2}})
+
+	assert.Nil(test, history.Undo(), "Unexpected error undoing a range operation")
+	assert.True(test, dictionary.IsEmpty(), "Range operations should be undone at once")
+}
+
+func TestHistoryLimit(test *testing.T) {
+	dictionary := NewEmptyDictionary()
+	history := dictionary.EnableHistory(2)
+
+	dictionary.Add(1, 1)
+	first := history.Snapshot()
+	dictionary.Add(2, 2)
+	history.Snapshot()
+	dictionary.Add(3, 3)
+	last := history.Snapshot()
+
+	assert.Nil(test, history.Undo(), "Unexpected error on Undo method")
+	assert.Nil(test, history.Undo(), "Unexpected error on Undo method")
+	assert.Equal(test, ErrNothingToUndo, history.Undo(), "Changes beyond the limit shouldn't be retained")
+	assert.Equal(test, KeyValueMap{1: 1}, *dictionary.Elements(), "Wrong elements after undoing up to the limit")
+
+	_, err := history.At(first)
+
+	assert.Equal(test, ErrVersionNotFound, err, "Snapshots beyond the limit shouldn't be retained")
+
+	snapshot, err := history.At(last)
+
+	assert.Nil(test, err, "Unexpected error on At method")
+	assert.Equal(test, KeyValueMap{1: 1, 2: 2, 3: 3}, *snapshot.Elements(), "Snapshots shouldn't be affected by undoing")
+}
+
+func TestSnapshotAndAtMethods(test *testing.T) {
+	dictionary, _ := NewDictionary([]KeyValueElement{{Key: "1Key", Value: 1}})
+	history := dictionary.EnableHistory(0)
+
+	initial := history.Snapshot()
+	dictionary.Set("1Key", 10)
+	dictionary.Add("2Key", 2)
+	current := history.Snapshot()
+
+	past, _ := history.At(initial)
+	present, _ := history.At(current)
+
+	assert.Equal(test, KeyValueMap{"1Key": 1}, *past.Elements(), "Wrong elements of a past version")
+	assert.Equal(test, KeyValueMap{"1Key": 10, "2Key": 2}, *present.Elements(), "Wrong elements of the current version")
+
+	_, err := history.At(current + 1)
+
+	assert.Equal(test, ErrVersionNotFound, err, "Non existing versions should return an error")
+
+	history.Disable()
+	dictionary.Add("3Key", 3)
+
+	assert.Nil(test, history.Undo(), "Changes recorded before disabling the history should be undoable")
+	assert.Equal(test, KeyValueMap{"1Key": 10, "3Key": 3}, *dictionary.Elements(), "Changes after disabling the history shouldn't be recorded")
+}
+
+func TestHistoryAfterDecoding(test *testing.T) {
+	dictionary, _ := NewDictionary([]KeyValueElement{{Key: "a", Value: 1}})
+	history := dictionary.EnableHistory(0)
+
+	dictionary.Add("b", 2)
+	before := history.Snapshot()
+
+	assert.Nil(test, dictionary.UnmarshalJSON([]byte(`{"c": 3}`)), "Unexpected error decoding the dictionary")
+	assert.False(test, history.CanUndo(), "Decoding should discard the recorded changes")
+
+	current, _ := history.At(history.Snapshot())
+
+	assert.Equal(test, KeyValueMap{"c": 3}, *current.Elements(), "Snapshots should mirror the decoded elements")
+
+	snapshot, _ := history.At(before)
+
+	assert.Equal(test, KeyValueMap{"a": 1, "b": 2}, *snapshot.Elements(), "Snapshots taken before decoding should be kept")
+
+	dictionary.Delete("c")
+
+	assert.Nil(test, history.Undo(), "Changes done after decoding should be undone")
+	assert.Equal(test, KeyValueMap{"c": 3}, *dictionary.Elements(), "Wrong elements undoing a change after decoding")
+}
+
+func TestHistoryRebuildsDivergedMirror(test *testing.T) {
+	dictionary, _ := NewDictionary([]KeyValueElement{{Key: "a", Value: 1}})
+	history := dictionary.EnableHistory(0)
+
+	// A mirror with other types can't follow the changes of the dictionary
+	history.mirror, _ = NewPersistentDictionary([]KeyValueElement{{Key: 1, Value: "1"}})
+
+	assert.NotPanics(test, func() {
+		dictionary.Add("b", 2)
+		dictionary.Add("c", 3)
+	}, "Failing to mirror a change shouldn't break the history")
+
+	snapshot, _ := history.At(history.Snapshot())
+
+	assert.Equal(test, KeyValueMap{"a": 1, "b": 2, "c": 3}, *snapshot.Elements(), "The mirror should be rebuilt from the dictionary")
+	assert.Nil(test, history.Undo(), "Unexpected error undoing after rebuilding the mirror")
+	assert.Equal(test, KeyValueMap{"a": 1, "b": 2}, *dictionary.Elements(), "Wrong elements undoing after rebuilding the mirror")
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// Package datatypes/dictionary provides an easy dictionary (key => value) homogeneous
+// struct management, making the iteration of a unique-key lists more powerful,
+// simple and clean, accepting primitives types and complex user structs as well.
+
+// This part of package contains the lazy iterators over dictionaries
+
+package dictionary
+
+import "iter"
+
+// Iter returns an iterator over the keys and values of the dictionary
+// Unlike Elements, it doesn't expose the internal storage of the dictionary
+func (dic *Dictionary) Iter() iter.Seq2[KeyElement, ValueElement] {
+	return func(yield func(KeyElement, ValueElement) bool) {
+		for key, value := range dic.elements {
+			if !yield(key, value) {
+				return
+			}
+		}
+	}
+}
+
+// IterKeys returns an iterator over the keys of the dictionary
+func (dic *Dictionary) IterKeys() iter.Seq[KeyElement] {
+	return func(yield func(KeyElement) bool) {
+		for key := range dic.elements {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
+
+// IterValues returns an iterator over the values of the dictionary
+func (dic *Dictionary) IterValues() iter.Seq[ValueElement] {
+	return func(yield func(ValueElement) bool) {
+		for _, value := range dic.elements {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// Package datatypes/dictionary provides an easy dictionary (key => value) homogeneous
+// struct management, making the iteration of a unique-key lists more powerful,
+// simple and clean, accepting primitives types and complex user structs as well.
+
+// This part of package contains the tests for the lazy iterators
+
+package dictionary
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIterMethod(test *testing.T) {
+	dictionary, _ := NewDictionary([]KeyValueElement{{"1Key", "1Value"}, {"2Key", "2Value"}})
+	visited := KeyValueMap{}
+
+	for key, value := range dictionary.Iter() {
+		visited[key] = value
+	}
+
+	assert.Equal(test, *dictionary.Elements(), visited, "Iter should visit every key and value")
+}
+
+func TestIterKeysAndValuesMethods(test *testing.T) {
+	dictionary, _ := NewDictionary([]KeyValueElement{{"1Key", "1Value"}, {"2Key", "2Value"}})
+
+	var keys []KeyElement
+	var values []ValueElement
+
+	for key := range dictionary.IterKeys() {
+		keys = append(keys, key)
+	}
+
+	for value := range dictionary.IterValues() {
+		values = append(values, value)
+	}
+
+	assert.ElementsMatch(test, dictionary.Keys(), keys, "IterKeys should visit every key")
+	assert.ElementsMatch(test, dictionary.Values(), values, "IterValues should visit every value")
+
+	for range dictionary.IterKeys() {
+		break
+	}
+}
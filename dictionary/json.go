@@ -0,0 +1,249 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// Package datatypes/dictionary provides an easy dictionary (key => value) homogeneous
+// struct management, making the iteration of a unique-key lists more powerful,
+// simple and clean, accepting primitives types and complex user structs as well.
+
+// This part of package contains the JSON encoding and decoding of dictionaries
+
+package dictionary
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"sort"
+)
+
+var (
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// jsonPair is the representation of the elements whose keys can't be JSON object keys
+type jsonPair struct {
+	Key   json.RawMessage `json:"key"`
+	Value json.RawMessage `json:"value"`
+}
+
+// MarshalJSON encodes the dictionary as a JSON object when the keys are strings
+// or implement encoding.TextMarshaler, otherwise it encodes it as an array of
+// {"key": ..., "value": ...} objects sorted by their encoded keys
+func (dic *Dictionary) MarshalJSON() ([]byte, error) {
+	if dic.IsEmpty() {
+		return []byte("{}"), nil
+	}
+
+	if isTextKey(dic.keyDefinition) {
+		object := make(map[string]ValueElement, dic.Size())
+
+		for key, value := range dic.elements {
+			text, err := keyToText(key)
+
+			if err != nil {
+				return nil, err
+			}
+
+			object[text] = value
+		}
+
+		return json.Marshal(object)
+	}
+
+	pairs := make([]jsonPair, 0, dic.Size())
+
+	for key, value := range dic.elements {
+		encodedKey, err := json.Marshal(key)
+
+		if err != nil {
+			return nil, err
+		}
+
+		encodedValue, err := json.Marshal(value)
+
+		if err != nil {
+			return nil, err
+		}
+
+		pairs = append(pairs, jsonPair{Key: encodedKey, Value: encodedValue})
+	}
+
+	sort.Slice(pairs, func(first, second int) bool {
+		return bytes.Compare(pairs[first].Key, pairs[second].Key) < 0
+	})
+
+	return json.Marshal(pairs)
+}
+
+// UnmarshalJSON decodes a JSON object or an array of {"key": ..., "value": ...}
+// objects replacing the elements of the dictionary
+// Keys and values are decoded into the types registered with DecodeAs or, if there are
+// none, into the types of the stored elements, so the types of a non-empty dictionary
+// are preserved on a round trip. Empty dictionaries without registered types decode them
+// as the standard library does for interface{} values. If any element can't be stored
+// it returns the error and the dictionary is left untouched
+func (dic *Dictionary) UnmarshalJSON(data []byte) error {
+	var elements []KeyValueElement
+	var err error
+
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		elements, err = dic.decodePairs(data)
+	} else {
+		elements, err = dic.decodeObject(data)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return dic.replace(elements)
+}
+
+// DecodeAs registers the types of the specified prototypes as the types
+// which the keys and values will be decoded into by UnmarshalJSON and UnmarshalBinary
+// A nil prototype keeps the default decoding for keys or values
+// It returns the same dictionary so it can be chained
+func (dic *Dictionary) DecodeAs(key KeyElement, value ValueElement) *Dictionary {
+	dic.keyDecoding = reflect.TypeOf(key)
+	dic.valueDecoding = reflect.TypeOf(value)
+
+	return dic
+}
+
+// decodings returns the types which the keys and values are decoded into, the registered
+// ones or the ones of the stored elements if there are no registered types
+func (dic *Dictionary) decodings() (reflect.Type, reflect.Type) {
+	keyDecoding, valueDecoding := dic.keyDecoding, dic.valueDecoding
+
+	if dic.IsEmpty() {
+		return keyDecoding, valueDecoding
+	}
+
+	if keyDecoding == nil {
+		keyDecoding = dic.keyDefinition
+	}
+
+	if valueDecoding == nil {
+		valueDecoding = dic.valueDefinition
+	}
+
+	return keyDecoding, valueDecoding
+}
+
+func (dic *Dictionary) decodeObject(data []byte) ([]KeyValueElement, error) {
+	var object map[string]json.RawMessage
+
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+
+	keyDecoding, valueDecoding := dic.decodings()
+	elements := make([]KeyValueElement, 0, len(object))
+
+	for text, raw := range object {
+		key, err := textToKey(text, keyDecoding)
+
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := decodeElement(raw, valueDecoding)
+
+		if err != nil {
+			return nil, err
+		}
+
+		elements = append(elements, KeyValueElement{Key: key, Value: value})
+	}
+
+	return elements, nil
+}
+
+func (dic *Dictionary) decodePairs(data []byte) ([]KeyValueElement, error) {
+	var pairs []jsonPair
+
+	if err := json.Unmarshal(data, &pairs); err != nil {
+		return nil, err
+	}
+
+	keyDecoding, valueDecoding := dic.decodings()
+	elements := make([]KeyValueElement, 0, len(pairs))
+
+	for _, pair := range pairs {
+		key, err := decodeElement(pair.Key, keyDecoding)
+
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := decodeElement(pair.Value, valueDecoding)
+
+		if err != nil {
+			return nil, err
+		}
+
+		elements = append(elements, KeyValueElement{Key: key, Value: value})
+	}
+
+	return elements, nil
+}
+
+func isTextKey(definition reflect.Type) bool {
+	if definition == nil {
+		return false
+	}
+
+	return definition.Implements(textMarshalerType) || definition.Kind() == reflect.String
+}
+
+func keyToText(key KeyElement) (string, error) {
+	if marshaler, ok := key.(encoding.TextMarshaler); ok {
+		text, err := marshaler.MarshalText()
+
+		return string(text), err
+	}
+
+	return reflect.ValueOf(key).String(), nil
+}
+
+func textToKey(text string, definition reflect.Type) (KeyElement, error) {
+	if definition == nil {
+		return text, nil
+	}
+
+	if reflect.PointerTo(definition).Implements(textUnmarshalerType) {
+		key := reflect.New(definition)
+
+		if err := key.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(text)); err != nil {
+			return nil, err
+		}
+
+		return key.Elem().Interface(), nil
+	}
+
+	if definition.Kind() == reflect.String {
+		return reflect.ValueOf(text).Convert(definition).Interface(), nil
+	}
+
+	return nil, ErrInvalidKeyValueElementType
+}
+
+func decodeElement(raw json.RawMessage, definition reflect.Type) (interface{}, error) {
+	if definition == nil {
+		var element interface{}
+		err := json.Unmarshal(raw, &element)
+
+		return element, err
+	}
+
+	element := reflect.New(definition)
+
+	if err := json.Unmarshal(raw, element.Interface()); err != nil {
+		return nil, err
+	}
+
+	return element.Elem().Interface(), nil
+}
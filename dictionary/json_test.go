@@ -0,0 +1,142 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// Package datatypes/dictionary provides an easy dictionary (key => value) homogeneous
+// struct management, making the iteration of a unique-key lists more powerful,
+// simple and clean, accepting primitives types and complex user structs as well.
+
+// This part of package contains the tests for the JSON encoding and decoding
+
+package dictionary
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type jsonTestKey struct {
+	Zone string
+	ID   int
+}
+
+func (key jsonTestKey) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("%s/%d", key.Zone, key.ID)), nil
+}
+
+func (key *jsonTestKey) UnmarshalText(text []byte) error {
+	parts := strings.SplitN(string(text), "/", 2)
+
+	if len(parts) != 2 {
+		return fmt.Errorf("invalid key %q", text)
+	}
+
+	key.Zone = parts[0]
+	_, err := fmt.Sscan(parts[1], &key.ID)
+
+	return err
+}
+
+type jsonTestValue struct {
+	Name string `json:"name"`
+}
+
+func TestMarshalJSONWithStringKeys(test *testing.T) {
+	dictionary, _ := NewDictionary([]KeyValueElement{{Key: "1Key", Value: 1}, {Key: "2Key", Value: 2}})
+
+	encoded, err := json.Marshal(dictionary)
+
+	assert.Nil(test, err, "Unexpected error encoding a dictionary")
+	assert.JSONEq(test, `{"1Key": 1, "2Key": 2}`, string(encoded), "Wrong encoded dictionary with string keys")
+
+	encoded, _ = json.Marshal(NewEmptyDictionary())
+
+	assert.Equal(test, `{}`, string(encoded), "Empty dictionaries should be encoded as an empty object")
+}
+
+func TestMarshalJSONWithNonStringKeys(test *testing.T) {
+	dictionary, _ := NewDictionary([]KeyValueElement{{Key: 2, Value: "two"}, {Key: 1, Value: "one"}})
+
+	encoded, err := json.Marshal(dictionary)
+
+	assert.Nil(test, err, "Unexpected error encoding a dictionary")
+	assert.Equal(test, `[{"key":1,"value":"one"},{"key":2,"value":"two"}]`, string(encoded), "Wrong encoded dictionary with non-string keys")
+}
+
+func TestMarshalJSONWithTextMarshalerKeys(test *testing.T) {
+	dictionary, _ := NewDictionary([]KeyValueElement{{Key: jsonTestKey{"eu", 1}, Value: jsonTestValue{"Jaime"}}})
+
+	encoded, err := json.Marshal(dictionary)
+
+	assert.Nil(test, err, "Unexpected error encoding a dictionary")
+	assert.JSONEq(test, `{"eu/1": {"name": "Jaime"}}`, string(encoded), "Wrong encoded dictionary with TextMarshaler keys")
+
+	decoded := NewEmptyDictionary().DecodeAs(jsonTestKey{}, jsonTestValue{})
+
+	assert.Nil(test, json.Unmarshal(encoded, decoded), "Unexpected error decoding TextUnmarshaler keys")
+	assert.Equal(test, dictionary.Elements(), decoded.Elements(), "Wrong round trip with TextMarshaler keys")
+	assert.Equal(test, dictionary.keyDefinition, decoded.keyDefinition, "Key definition should be preserved on round trip")
+	assert.Equal(test, dictionary.valueDefinition, decoded.valueDefinition, "Value definition should be preserved on round trip")
+}
+
+func TestUnmarshalJSONMethod(test *testing.T) {
+	dictionary := NewEmptyDictionary()
+
+	assert.Nil(test, json.Unmarshal([]byte(`{"1Key": 1, "2Key": 2}`), dictionary), "Unexpected error decoding an object")
+	assert.Equal(test, KeyValueMap{"1Key": 1.0, "2Key": 2.0}, *dictionary.Elements(), "Wrong decoded object")
+
+	dictionary = NewEmptyDictionary()
+
+	assert.Nil(test, json.Unmarshal([]byte(`[{"key": 1, "value": "one"}]`), dictionary), "Unexpected error decoding pairs")
+	assert.Equal(test, KeyValueMap{1.0: "one"}, *dictionary.Elements(), "Wrong decoded pairs")
+
+	err := json.Unmarshal([]byte(`[{"key": 1, "value": "one"}, {"key": 1, "value": "uno"}]`), dictionary)
+
+	assert.Equal(test, ErrDuplicatedKey, err, "Decoding duplicated keys should return an error")
+
+	err = json.Unmarshal([]byte(`[{"key": 1, "value": "one"}, {"key": "2", "value": "two"}]`), NewEmptyDictionary())
+
+	assert.Equal(test, ErrInvalidKeyValueElementType, err, "Decoding heterogeneous keys should return an error")
+
+	err = json.Unmarshal([]byte(`[{"key": {"id": 1}, "value": "one"}]`), NewEmptyDictionary())
+
+	assert.Equal(test, ErrInvalidKeyValueElementType, err, "Decoding non-hashable keys should return an error")
+
+	err = json.Unmarshal([]byte(`[{"key": "2", "value": "two"}]`), dictionary)
+
+	assert.NotNil(test, err, "Decoding keys of other types than the stored ones should return an error")
+	assert.Equal(test, KeyValueMap{1.0: "one"}, *dictionary.Elements(), "Failed decodings should leave the dictionary untouched")
+}
+
+func TestUnmarshalJSONKeepsStoredTypes(test *testing.T) {
+	dictionary, _ := NewDictionary([]KeyValueElement{{Key: 2, Value: "b"}})
+
+	assert.Nil(test, json.Unmarshal([]byte(`[{"key": 1, "value": "a"}]`), dictionary), "Unexpected error decoding into a non-empty dictionary")
+	assert.Equal(test, KeyValueMap{1: "a"}, *dictionary.Elements(), "Keys and values should be decoded into the stored types")
+	assert.Equal(test, reflect.TypeOf(0), dictionary.keyDefinition, "Key definition should be preserved decoding into a non-empty dictionary")
+
+	encoded, _ := json.Marshal(dictionary)
+
+	assert.Nil(test, json.Unmarshal(encoded, dictionary), "Unexpected error on a round trip of a non-empty dictionary")
+	assert.Equal(test, KeyValueMap{1: "a"}, *dictionary.Elements(), "Wrong round trip of a non-empty dictionary")
+}
+
+func TestDecodeAsRoundTrip(test *testing.T) {
+	dictionary, _ := NewDictionary([]KeyValueElement{{Key: 1, Value: jsonTestValue{"one"}}, {Key: 2, Value: jsonTestValue{"two"}}})
+
+	encoded, _ := json.Marshal(dictionary)
+	decoded := NewEmptyDictionary().DecodeAs(0, jsonTestValue{})
+
+	assert.Nil(test, json.Unmarshal(encoded, decoded), "Unexpected error decoding into registered types")
+	assert.Equal(test, dictionary.Elements(), decoded.Elements(), "Wrong round trip with registered types")
+	assert.Equal(test, dictionary.keyDefinition, decoded.keyDefinition, "Key definition should be preserved on round trip")
+
+	integers := NewEmptyDictionary().DecodeAs(0, nil)
+
+	assert.Equal(test, ErrInvalidKeyValueElementType, json.Unmarshal([]byte(`{"1": 1}`), integers), "Object keys can't be decoded into non-text types")
+}
@@ -0,0 +1,210 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// Package datatypes/dictionary provides an easy dictionary (key => value) homogeneous
+// struct management, making the iteration of a unique-key lists more powerful,
+// simple and clean, accepting primitives types and complex user structs as well.
+
+// This part of package contains the RFC 6902 JSON Patch export and import
+
+package dictionary
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+var (
+	pointerEscaper   = strings.NewReplacer("~", "~0", "/", "~1")
+	pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+)
+
+// jsonPatchOperation is a single operation of a JSON Patch document
+type jsonPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// JSONPatch exports the patch as a RFC 6902 JSON Patch document
+// Removed and changed keys are preceded by a test operation with their old
+// values, so applying the document detects the same conflicts than Apply.
+// Keys must be strings or implement encoding.TextMarshaler, otherwise it returns ErrNonTextKey
+func (patch *Patch) JSONPatch() ([]byte, error) {
+	operations := []jsonPatchOperation{}
+
+	add := func(op string, key KeyElement, value ValueElement, hasValue bool) error {
+		path, err := keyToPointer(key)
+
+		if err != nil {
+			return err
+		}
+
+		operation := jsonPatchOperation{Op: op, Path: path}
+
+		if hasValue {
+			if operation.Value, err = json.Marshal(value); err != nil {
+				return err
+			}
+		}
+
+		operations = append(operations, operation)
+
+		return nil
+	}
+
+	for _, element := range patch.Removed {
+		if err := add("test", element.Key, element.Value, true); err != nil {
+			return nil, err
+		}
+
+		if err := add("remove", element.Key, nil, false); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, change := range patch.Changed {
+		if err := add("test", change.Key, change.Old, true); err != nil {
+			return nil, err
+		}
+
+		if err := add("replace", change.Key, change.New, true); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, element := range patch.Added {
+		if err := add("add", element.Key, element.Value, true); err != nil {
+			return nil, err
+		}
+	}
+
+	return json.Marshal(operations)
+}
+
+// ApplyJSONPatch applies a RFC 6902 JSON Patch document to the dictionary atomically
+// Only the add, remove, replace and test operations over first level paths are
+// supported, otherwise it returns ErrInvalidJSONPatch. If a test operation fails
+// or a removed or replaced key doesn't exist it returns ErrPatchConflict.
+// Keys and values are decoded into the types of the dictionary, or into the
+// ones registered with DecodeAs when it's empty. Either way, if the document
+// can't be applied the dictionary is left untouched
+func (dic *Dictionary) ApplyJSONPatch(data []byte) error {
+	var operations []jsonPatchOperation
+
+	if err := json.Unmarshal(data, &operations); err != nil {
+		return ErrInvalidJSONPatch
+	}
+
+	staged := dic.clone()
+	tx := dic.Begin()
+
+	for _, operation := range operations {
+		key, err := staged.pointerToKey(operation.Path)
+
+		if err != nil {
+			return err
+		}
+
+		var value ValueElement
+
+		if operation.Op != "remove" {
+			if value, err = staged.decodeValue(operation.Value); err != nil {
+				return err
+			}
+		}
+
+		switch operation.Op {
+		case "test":
+			if !staged.hasValue(key, value) {
+				return ErrPatchConflict
+			}
+		case "remove":
+			if staged.Delete(key) != nil {
+				return ErrPatchConflict
+			}
+
+			tx.Delete(key)
+		case "replace":
+			if !staged.Contains(key) {
+				return ErrPatchConflict
+			}
+
+			if err := staged.Set(key, value); err != nil {
+				return err
+			}
+
+			tx.Set(key, value)
+		case "add":
+			// Adding an existing key replaces its value as RFC 6902 specifies
+			if staged.Contains(key) {
+				err = staged.Set(key, value)
+				tx.Set(key, value)
+			} else {
+				err = staged.Add(key, value)
+				tx.Add(key, value)
+			}
+
+			if err != nil {
+				return err
+			}
+		default:
+			return ErrInvalidJSONPatch
+		}
+	}
+
+	return tx.Commit()
+}
+
+// pointerToKey decodes the key referenced by a first level JSON Pointer
+func (dic *Dictionary) pointerToKey(pointer string) (KeyElement, error) {
+	if !strings.HasPrefix(pointer, "/") || strings.Contains(pointer[1:], "/") {
+		return nil, ErrInvalidJSONPatch
+	}
+
+	definition := dic.keyDecoding
+
+	if !dic.IsEmpty() {
+		definition = dic.keyDefinition
+	}
+
+	return textToKey(pointerUnescaper.Replace(pointer[1:]), definition)
+}
+
+// decodeValue decodes a value of a JSON Patch operation
+func (dic *Dictionary) decodeValue(raw json.RawMessage) (ValueElement, error) {
+	if len(raw) == 0 {
+		return nil, ErrInvalidJSONPatch
+	}
+
+	definition := dic.valueDecoding
+
+	if !dic.IsEmpty() {
+		definition = dic.valueDefinition
+	}
+
+	value, err := decodeElement(raw, definition)
+
+	if err != nil {
+		return nil, ErrInvalidKeyValueElementType
+	}
+
+	return value, nil
+}
+
+// keyToPointer encodes the key as a first level JSON Pointer
+func keyToPointer(key KeyElement) (string, error) {
+	if !isTextKey(reflect.TypeOf(key)) {
+		return "", ErrNonTextKey
+	}
+
+	text, err := keyToText(key)
+
+	if err != nil {
+		return "", err
+	}
+
+	return "/" + pointerEscaper.Replace(text), nil
+}
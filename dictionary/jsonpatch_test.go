@@ -0,0 +1,79 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// Package datatypes/dictionary provides an easy dictionary (key => value) homogeneous
+// struct management, making the iteration of a unique-key lists more powerful,
+// simple and clean, accepting primitives types and complex user structs as well.
+
+// This part of package contains the tests for the RFC 6902 JSON Patch export and import
+
+package dictionary
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONPatchMethod(test *testing.T) {
+	from, _ := NewDictionary([]KeyValueElement{{Key: "a/b", Value: 1}, {Key: "c~d", Value: 2}})
+	to, _ := NewDictionary([]KeyValueElement{{Key: "c~d", Value: 20}, {Key: "e", Value: 3}})
+
+	patch, _ := Diff(from, to)
+	document, err := patch.JSONPatch()
+
+	assert.Nil(test, err, "Unexpected error exporting a JSON Patch")
+	assert.JSONEq(test, `[
+		{"op": "test", "path": "/a~1b", "value": 1},
+		{"op": "remove", "path": "/a~1b"},
+		{"op": "test", "path": "/c~0d", "value": 2},
+		{"op": "replace", "path": "/c~0d", "value": 20},
+		{"op": "add", "path": "/e", "value": 3}
+	]`, string(document), "Wrong exported JSON Patch")
+
+	assert.Nil(test, from.ApplyJSONPatch(document), "Unexpected error applying an exported JSON Patch")
+	assert.Equal(test, to.Elements(), from.Elements(), "Applying an exported JSON Patch should transform the dictionary")
+	assert.Equal(test, ErrPatchConflict, from.ApplyJSONPatch(document), "Failed test operations should return a conflict")
+	assert.Equal(test, to.Elements(), from.Elements(), "Conflicting JSON Patches should leave the dictionary untouched")
+
+	numbers, _ := NewDictionary([]KeyValueElement{{Key: 1, Value: 1}})
+	patch, _ = Diff(NewEmptyDictionary(), numbers)
+	_, err = patch.JSONPatch()
+
+	assert.Equal(test, ErrNonTextKey, err, "Non text keys can't be exported as JSON Patch")
+}
+
+func TestApplyJSONPatchMethod(test *testing.T) {
+	dictionary, _ := NewDictionary([]KeyValueElement{{Key: "a", Value: 1}})
+
+	err := dictionary.ApplyJSONPatch([]byte(`[
+		{"op": "add", "path": "/b", "value": 2},
+		{"op": "add", "path": "/a", "value": 10},
+		{"op": "test", "path": "/a", "value": 10},
+		{"op": "remove", "path": "/b"}
+	]`))
+
+	assert.Nil(test, err, "Unexpected error applying a JSON Patch")
+	assert.Equal(test, KeyValueMap{"a": 10}, *dictionary.Elements(), "Wrong elements applying a JSON Patch")
+
+	for document, expected := range map[string]error{
+		`[{"op": "remove", "path": "/z"}]`:              ErrPatchConflict,
+		`[{"op": "replace", "path": "/z", "value": 1}]`: ErrPatchConflict,
+		`[{"op": "add", "path": "/b", "value": "two"}]`: ErrInvalidKeyValueElementType,
+		`[{"op": "add", "path": "/b"}]`:                 ErrInvalidJSONPatch,
+		`[{"op": "move", "from": "/a", "path": "/b"}]`:  ErrInvalidJSONPatch,
+		`[{"op": "add", "path": "/a/b", "value": 1}]`:   ErrInvalidJSONPatch,
+		`{"op": "add"}`: ErrInvalidJSONPatch,
+		`[{"op": "add", "path": "/c", "value": 3}, {}]`: ErrInvalidJSONPatch,
+	} {
+		assert.Equal(test, expected, dictionary.ApplyJSONPatch([]byte(document)), "Wrong error applying %s", document)
+	}
+
+	assert.Equal(test, KeyValueMap{"a": 10}, *dictionary.Elements(), "Failed JSON Patches should leave the dictionary untouched")
+
+	empty := NewEmptyDictionary()
+
+	assert.Nil(test, empty.ApplyJSONPatch([]byte(`[{"op": "add", "path": "/a", "value": "x"}]`)), "Unexpected error applying a JSON Patch to an empty dictionary")
+	assert.Equal(test, KeyValueMap{"a": "x"}, *empty.Elements(), "Wrong elements applying a JSON Patch to an empty dictionary")
+}
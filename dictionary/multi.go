@@ -0,0 +1,295 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// Package datatypes/dictionary provides an easy dictionary (key => value) homogeneous
+// struct management, making the iteration of a unique-key lists more powerful,
+// simple and clean, accepting primitives types and complex user structs as well.
+
+// This part of package contains the multi dictionary behaviour
+
+package dictionary
+
+import (
+	"errors"
+	"reflect"
+
+	"github.com/jaimelopez/datatypes/collection"
+	"github.com/jaimelopez/datatypes/generic"
+)
+
+// MultiDictionary represents a dictionary which maps every key to a collection of values
+// Keys and values must be homogeneous as in a Dictionary, and the values
+// of a key are unique since they're stored in a collection
+type MultiDictionary struct {
+	keyDefinition   reflect.Type
+	valueDefinition reflect.Type
+	elements        map[KeyElement]*collection.Collection
+	size            int
+}
+
+// Put adds the value to the values of the key
+// If the key-value entry already exists it returns ErrDuplicatedEntry
+// and if the key can't be compared it returns ErrInvalidKeyType
+func (dic *MultiDictionary) Put(key KeyElement, value ValueElement) error {
+	err := dic.PutAll(key, []ValueElement{value})
+
+	if errors.Is(err, ErrDuplicatedEntry) {
+		return ErrDuplicatedEntry
+	}
+
+	return err
+}
+
+// PutAll adds a range (slice) of values to the values of the key
+// If the parameter can't be converted to a iterable data type it's return an error.
+// The range is added all or nothing, so if any value can't be added
+// the dictionary is left untouched. Values already stored for the key or repeated
+// in the range return a *DuplicateEntryError, which is considered equal
+// to ErrDuplicatedEntry by errors.Is
+func (dic *MultiDictionary) PutAll(key KeyElement, values collection.ElementList) error {
+	slice, err := generic.ToSlice(values)
+
+	if err != nil {
+		return err
+	}
+
+	if len(slice) == 0 {
+		return nil
+	}
+
+	if !isComparableKey(key) {
+		return ErrInvalidKeyType
+	}
+
+	keyDefinition, valueDefinition := dic.keyDefinition, dic.valueDefinition
+
+	if dic.IsEmpty() {
+		keyDefinition, valueDefinition = reflect.TypeOf(key), reflect.TypeOf(slice[0])
+	}
+
+	if reflect.TypeOf(key) != keyDefinition {
+		return ErrInvalidKeyValueElementType
+	}
+
+	for _, value := range slice {
+		if reflect.TypeOf(value) != valueDefinition {
+			return ErrInvalidKeyValueElementType
+		}
+	}
+
+	current, exists := dic.elements[key]
+
+	if !exists {
+		current = collection.NewEmptyCollection()
+	}
+
+	if err := current.AddRange(slice); err != nil {
+		var duplicate *collection.DuplicateError
+
+		if errors.As(err, &duplicate) {
+			return &DuplicateEntryError{Key: key, Value: duplicate.Value, Index: duplicate.Index}
+		}
+
+		return err
+	}
+
+	dic.keyDefinition, dic.valueDefinition = keyDefinition, valueDefinition
+	dic.elements[key] = current
+	dic.size += len(slice)
+
+	return nil
+}
+
+// Get returns a copy of the values of the key
+// If the key doesn't exist the returned collection is empty
+// and if it can't be compared it returns ErrInvalidKeyType
+func (dic *MultiDictionary) Get(key KeyElement) (*collection.Collection, error) {
+	if !isComparableKey(key) {
+		return nil, ErrInvalidKeyType
+	}
+
+	values := collection.NewEmptyCollection()
+
+	if current, exists := dic.elements[key]; exists {
+		values.AddCollection(current)
+	}
+
+	return values, nil
+}
+
+// GetFirst returns the first value added to the key
+// If the key doesn't exist it returns ErrElementNotFound
+// and if it can't be compared it returns ErrInvalidKeyType
+func (dic *MultiDictionary) GetFirst(key KeyElement) (ValueElement, error) {
+	if !isComparableKey(key) {
+		return nil, ErrInvalidKeyType
+	}
+
+	current, exists := dic.elements[key]
+
+	if !exists {
+		return nil, ErrElementNotFound
+	}
+
+	return current.First(), nil
+}
+
+// Remove deletes the value from the values of the key
+// The key is removed as well when it has no more values.
+// If the key-value entry doesn't exist it returns ErrElementNotFound
+// and if the key can't be compared it returns ErrInvalidKeyType
+func (dic *MultiDictionary) Remove(key KeyElement, value ValueElement) error {
+	if !isComparableKey(key) {
+		return ErrInvalidKeyType
+	}
+
+	if !dic.ContainsEntry(key, value) {
+		return ErrElementNotFound
+	}
+
+	current := dic.elements[key]
+	current.Delete(value)
+	dic.size--
+
+	if current.IsEmpty() {
+		delete(dic.elements, key)
+	}
+
+	return nil
+}
+
+// RemoveAll deletes the key and all its values
+// If the key doesn't exist it returns ErrElementNotFound
+// and if it can't be compared it returns ErrInvalidKeyType
+func (dic *MultiDictionary) RemoveAll(key KeyElement) error {
+	if !isComparableKey(key) {
+		return ErrInvalidKeyType
+	}
+
+	current, exists := dic.elements[key]
+
+	if !exists {
+		return ErrElementNotFound
+	}
+
+	dic.size -= current.Size()
+	delete(dic.elements, key)
+
+	return nil
+}
+
+// Contains checks if the specified key has any value in the dictionary
+// Keys which can't be compared are never contained
+func (dic *MultiDictionary) Contains(key KeyElement) bool {
+	if !isComparableKey(key) {
+		return false
+	}
+
+	_, exists := dic.elements[key]
+
+	return exists
+}
+
+// ContainsEntry checks if the specified value is one of the values of the key
+// Keys which can't be compared are never contained
+func (dic *MultiDictionary) ContainsEntry(key KeyElement, value ValueElement) bool {
+	if !isComparableKey(key) {
+		return false
+	}
+
+	current, exists := dic.elements[key]
+
+	return exists && current.Contains(value)
+}
+
+// Keys returns all the keys in the dictionary as a list of KeyElement
+func (dic *MultiDictionary) Keys() []KeyElement {
+	keys := []KeyElement{}
+
+	for key := range dic.elements {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+// Entries returns all the key-value entries of the dictionary
+// The entries of the same key are returned together in the order their values were added
+func (dic *MultiDictionary) Entries() []KeyValueElement {
+	entries := make([]KeyValueElement, 0, dic.size)
+
+	for key, values := range dic.elements {
+		for _, value := range values.Elements() {
+			entries = append(entries, KeyValueElement{key, value})
+		}
+	}
+
+	return entries
+}
+
+// Invert returns a new multi dictionary whose keys are the values of the instanced one
+// and whose values are the keys they belong to
+// The values must be usable as keys, otherwise it returns ErrInvalidKeyType
+func (dic *MultiDictionary) Invert() (*MultiDictionary, error) {
+	inverted := NewEmptyMultiDictionary()
+
+	for key, values := range dic.elements {
+		for _, value := range values.Elements() {
+			if err := inverted.Put(value, key); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return inverted, nil
+}
+
+// Size returns the number of key-value entries inside the dictionary
+func (dic *MultiDictionary) Size() int {
+	return dic.size
+}
+
+// KeyCount returns the number of distinct keys inside the dictionary
+func (dic *MultiDictionary) KeyCount() int {
+	return len(dic.elements)
+}
+
+// IsEmpty checks if the dictionary is empty or not
+func (dic *MultiDictionary) IsEmpty() bool {
+	return dic.Size() == 0
+}
+
+// isComparableKey checks if the key can be used as a map key
+// Keys which can't, like slices or maps, would panic
+func isComparableKey(key KeyElement) bool {
+	return key == nil || reflect.ValueOf(key).Comparable()
+}
+
+// NewEmptyMultiDictionary instances a new empty multi dictionary
+func NewEmptyMultiDictionary() *MultiDictionary {
+	return &MultiDictionary{elements: make(map[KeyElement]*collection.Collection)}
+}
+
+// NewMultiDictionary allows to instance a new MultiDictionary with a group of key-value elements
+// The keys can be repeated but every key-value entry must be unique
+// The elements are added all or nothing, so if any of them can't be added it returns
+// an empty dictionary. Repeated entries return a *DuplicateEntryError with
+// the position of the element, which is considered equal to ErrDuplicatedEntry by errors.Is
+func NewMultiDictionary(elements []KeyValueElement) (*MultiDictionary, error) {
+	dictionary := NewEmptyMultiDictionary()
+
+	for position, element := range elements {
+		err := dictionary.Put(element.Key, element.Value)
+
+		if errors.Is(err, ErrDuplicatedEntry) {
+			err = &DuplicateEntryError{Key: element.Key, Value: element.Value, Index: position}
+		}
+
+		if err != nil {
+			return NewEmptyMultiDictionary(), err
+		}
+	}
+
+	return dictionary, nil
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// Package datatypes/dictionary provides an easy dictionary (key => value) homogeneous
+// struct management, making the iteration of a unique-key lists more powerful,
+// simple and clean, accepting primitives types and complex user structs as well.
+
+// This part of package contains the tests for the multi dictionary behaviour
+
+package dictionary
+
+import (
+	"testing"
+
+	"github.com/jaimelopez/datatypes/collection"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiDictionaryPutMethods(test *testing.T) {
+	dictionary := NewEmptyMultiDictionary()
+
+	assert.Nil(test, dictionary.Put("Accept", "text/html"), "Unexpected error putting a value")
+	assert.Nil(test, dictionary.Put("Accept", "application/json"), "Repeated keys should be allowed")
+	assert.Equal(test, ErrDuplicatedEntry, dictionary.Put("Accept", "text/html"), "Repeated entries should return an error")
+	assert.Equal(test, ErrInvalidKeyValueElementType, dictionary.Put("Accept", 1), "Values must be homogeneous")
+	assert.Equal(test, ErrInvalidKeyValueElementType, dictionary.Put(1, "text/html"), "Keys must be homogeneous")
+
+	assert.Nil(test, dictionary.PutAll("Vary", []string{"Accept", "Origin"}), "Unexpected error putting several values")
+	err := dictionary.PutAll("Vary", []string{"Cookie", "Origin"})
+
+	assert.Equal(test, &DuplicateEntryError{Key: "Vary", Value: "Origin", Index: 1}, err, "Repeated entries in a range should return an error")
+	assert.ErrorIs(test, err, ErrDuplicatedEntry, "Repeated entries in a range should be considered ErrDuplicatedEntry")
+	assert.Equal(test, &DuplicateEntryError{Key: "Vary", Value: "Cookie", Index: 1}, dictionary.PutAll("Vary", []string{"Cookie", "Cookie"}), "Entries repeated in a range should return an error")
+	assert.Equal(test, ErrInvalidKeyValueElementType, dictionary.PutAll("Vary", []ValueElement{"Cookie", 1}), "Heterogeneous ranges should return an error")
+
+	values, _ := dictionary.Get("Vary")
+
+	assert.Equal(test, []collection.Element{"Accept", "Origin"}, values.Elements(), "Failed PutAll calls should leave the values untouched")
+
+	assert.Equal(test, 4, dictionary.Size(), "Size should count all the entries")
+	assert.Equal(test, 2, dictionary.KeyCount(), "KeyCount should count the distinct keys")
+	assert.ElementsMatch(test, []KeyValueElement{
+		{Key: "Accept", Value: "text/html"},
+		{Key: "Accept", Value: "application/json"},
+		{Key: "Vary", Value: "Accept"},
+		{Key: "Vary", Value: "Origin"},
+	}, dictionary.Entries(), "Wrong entries returned by Entries method")
+
+	assert.Equal(test, ErrInvalidKeyType, NewEmptyMultiDictionary().Put([]int{1}, 1), "Non-comparable keys should return an error")
+}
+
+func TestNewMultiDictionaryFunction(test *testing.T) {
+	dictionary, err := NewMultiDictionary([]KeyValueElement{{Key: "a", Value: 1}, {Key: "b", Value: 2}, {Key: "a", Value: 1}})
+
+	assert.Equal(test, &DuplicateEntryError{Key: "a", Value: 1, Index: 2}, err, "Repeated entries should return an error with their position")
+	assert.True(test, dictionary.IsEmpty(), "Failed constructions shouldn't keep any element")
+
+	dictionary, err = NewMultiDictionary([]KeyValueElement{{Key: "a", Value: 1}, {Key: "b", Value: "2"}})
+
+	assert.Equal(test, ErrInvalidKeyValueElementType, err, "Heterogeneous elements should return an error")
+	assert.True(test, dictionary.IsEmpty(), "Failed constructions shouldn't keep any element")
+}
+
+func TestMultiDictionaryNonComparableKeys(test *testing.T) {
+	dictionary, _ := NewMultiDictionary([]KeyValueElement{{Key: "a", Value: 1}})
+	key := []int{1}
+
+	_, err := dictionary.Get(key)
+
+	assert.Equal(test, ErrInvalidKeyType, err, "Get method should return an error for non-comparable keys")
+
+	_, err = dictionary.GetFirst(key)
+
+	assert.Equal(test, ErrInvalidKeyType, err, "GetFirst method should return an error for non-comparable keys")
+	assert.Equal(test, ErrInvalidKeyType, dictionary.Remove(key, 1), "Remove method should return an error for non-comparable keys")
+	assert.Equal(test, ErrInvalidKeyType, dictionary.RemoveAll(key), "RemoveAll method should return an error for non-comparable keys")
+	assert.False(test, dictionary.Contains(key), "Non-comparable keys should never be contained")
+	assert.False(test, dictionary.ContainsEntry(map[string]int{}, 1), "Non-comparable keys should never be contained")
+	assert.Equal(test, ErrInvalidKeyType, dictionary.Put(struct{ Field interface{} }{[]int{1}}, 1), "Keys holding non-comparable values should return an error")
+}
+
+func TestMultiDictionaryGetMethods(test *testing.T) {
+	dictionary, _ := NewMultiDictionary([]KeyValueElement{{Key: "a", Value: 1}, {Key: "a", Value: 2}})
+
+	values, err := dictionary.Get("a")
+
+	assert.Nil(test, err, "Unexpected error on Get method")
+	assert.Equal(test, []collection.Element{1, 2}, values.Elements(), "Get method should return all the values of the key")
+
+	missing, _ := dictionary.Get("b")
+
+	assert.True(test, missing.IsEmpty(), "Get method should return an empty collection for missing keys")
+
+	values.Add(3)
+
+	assert.False(test, dictionary.ContainsEntry("a", 3), "Get method should return a copy of the values")
+
+	first, err := dictionary.GetFirst("a")
+
+	assert.Nil(test, err, "Unexpected error on GetFirst method")
+	assert.Equal(test, 1, first, "GetFirst method should return the first added value")
+
+	_, err = dictionary.GetFirst("b")
+
+	assert.Equal(test, ErrElementNotFound, err, "GetFirst method should return an error for missing keys")
+}
+
+func TestMultiDictionaryRemoveMethods(test *testing.T) {
+	dictionary, _ := NewMultiDictionary([]KeyValueElement{{Key: "a", Value: 1}, {Key: "a", Value: 2}, {Key: "b", Value: 3}})
+
+	assert.Nil(test, dictionary.Remove("a", 1), "Unexpected error removing an entry")
+	assert.False(test, dictionary.ContainsEntry("a", 1), "Removed entries shouldn't be contained")
+	assert.True(test, dictionary.ContainsEntry("a", 2), "Remove method should keep the other values of the key")
+	assert.Equal(test, ErrElementNotFound, dictionary.Remove("a", 1), "Removing a missing entry should return an error")
+
+	assert.Nil(test, dictionary.Remove("a", 2), "Unexpected error removing the last entry of a key")
+	assert.False(test, dictionary.Contains("a"), "Keys without values should be removed")
+
+	assert.Nil(test, dictionary.RemoveAll("b"), "Unexpected error removing a key")
+	assert.Equal(test, ErrElementNotFound, dictionary.RemoveAll("b"), "Removing a missing key should return an error")
+	assert.True(test, dictionary.IsEmpty(), "Dictionary should be empty after removing all the entries")
+	assert.Nil(test, dictionary.Put(1, 1), "Empty dictionaries should accept any type again")
+}
+
+func TestMultiDictionaryInvertMethod(test *testing.T) {
+	dictionary, _ := NewMultiDictionary([]KeyValueElement{{Key: "a", Value: 1}, {Key: "a", Value: 2}, {Key: "b", Value: 1}})
+
+	inverted, err := dictionary.Invert()
+
+	assert.Nil(test, err, "Unexpected error inverting a multi dictionary")
+	ones, _ := inverted.Get(1)
+	twos, _ := inverted.Get(2)
+
+	assert.ElementsMatch(test, []collection.Element{"a", "b"}, ones.Elements(), "Inverted keys should map to all their original keys")
+	assert.Equal(test, []collection.Element{"a"}, twos.Elements(), "Wrong inverted values")
+	assert.Equal(test, dictionary.Size(), inverted.Size(), "Inverting should keep all the entries")
+
+	slices, _ := NewMultiDictionary([]KeyValueElement{{Key: "a", Value: []int{1}}})
+	_, err = slices.Invert()
+
+	assert.Equal(test, ErrInvalidKeyType, err, "Non-comparable values can't be inverted")
+}
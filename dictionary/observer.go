@@ -0,0 +1,106 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// Package datatypes/dictionary provides an easy dictionary (key => value) homogeneous
+// struct management, making the iteration of a unique-key lists more powerful,
+// simple and clean, accepting primitives types and complex user structs as well.
+
+// This part of package contains the change notifications of dictionaries
+
+package dictionary
+
+import "github.com/jaimelopez/datatypes/internal/observer"
+
+// EventType represents the kind of change of a dictionary
+type EventType int
+
+const (
+	// AddEvent is notified when a key is added
+	AddEvent EventType = iota
+	// SetEvent is notified when the value of a key is replaced
+	SetEvent
+	// DeleteEvent is notified when a key is deleted
+	DeleteEvent
+	// ExtractEvent is notified when a key is extracted
+	ExtractEvent
+	// ResetEvent is notified when all the elements are replaced at once,
+	// like decoding the dictionary with UnmarshalJSON or Scan
+	ResetEvent
+)
+
+// Event represents a change of a dictionary
+// Old is the value stored under the key before the change and New
+// the one stored after it, being nil when they don't apply
+type Event struct {
+	Type EventType
+	Key  KeyElement
+	Old  ValueElement
+	New  ValueElement
+}
+
+// Subscription represents a registered listener of a dictionary
+type Subscription struct {
+	unsubscribe func()
+}
+
+// Unsubscribe stops notifying the listener
+func (subscription *Subscription) Unsubscribe() {
+	subscription.unsubscribe()
+}
+
+// Subscribe registers a listener which is called after every change of the dictionary
+// The changes done by AddRange or by transactions are notified all together
+// once the operation finishes.
+// Listeners are called synchronously by the goroutine which modifies the dictionary
+func (dic *Dictionary) Subscribe(f func([]Event)) *Subscription {
+	if dic.observers == nil {
+		dic.observers = new(observer.Hub[Event])
+	}
+
+	return &Subscription{unsubscribe: dic.observers.Subscribe(f)}
+}
+
+// OnAdd registers a listener which is called for every added key
+func (dic *Dictionary) OnAdd(f func(Event)) *Subscription {
+	return dic.on(AddEvent, f)
+}
+
+// OnSet registers a listener which is called for every replaced value
+func (dic *Dictionary) OnSet(f func(Event)) *Subscription {
+	return dic.on(SetEvent, f)
+}
+
+// OnDelete registers a listener which is called for every deleted key
+func (dic *Dictionary) OnDelete(f func(Event)) *Subscription {
+	return dic.on(DeleteEvent, f)
+}
+
+// OnExtract registers a listener which is called for every extracted key
+func (dic *Dictionary) OnExtract(f func(Event)) *Subscription {
+	return dic.on(ExtractEvent, f)
+}
+
+// OnReset registers a listener which is called every time all the elements are replaced
+func (dic *Dictionary) OnReset(f func(Event)) *Subscription {
+	return dic.on(ResetEvent, f)
+}
+
+func (dic *Dictionary) on(kind EventType, f func(Event)) *Subscription {
+	return dic.Subscribe(func(events []Event) {
+		for _, event := range events {
+			if event.Type == kind {
+				f(event)
+			}
+		}
+	})
+}
+
+func (dic *Dictionary) notify(event Event) {
+	dic.observers.Notify(event)
+}
+
+// batch groups the notifications until the returned function is called
+func (dic *Dictionary) batch() func() {
+	return dic.observers.Batch()
+}
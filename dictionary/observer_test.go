@@ -0,0 +1,94 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// Package datatypes/dictionary provides an easy dictionary (key => value) homogeneous
+// struct management, making the iteration of a unique-key lists more powerful,
+// simple and clean, accepting primitives types and complex user structs as well.
+
+// This part of package contains the tests for the change notifications
+
+package dictionary
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscribeMethod(test *testing.T) {
+	dictionary, _ := NewDictionary([]KeyValueElement{{Key: "1Key", Value: 1}, {Key: "2Key", Value: 2}})
+	received := [][]Event{}
+
+	subscription := dictionary.Subscribe(func(events []Event) {
+		received = append(received, events)
+	})
+
+	dictionary.Add("3Key", 3)
+	dictionary.Set("1Key", 10)
+	dictionary.Delete("2Key")
+	dictionary.ExtractKey("3Key")
+	dictionary.Extract()
+
+	assert.Equal(test, [][]Event{
+		{{Type: AddEvent, Key: "3Key", New: 3}},
+		{{Type: SetEvent, Key: "1Key", Old: 1, New: 10}},
+		{{Type: DeleteEvent, Key: "2Key", Old: 2}},
+		{{Type: ExtractEvent, Key: "3Key", Old: 3}},
+		{{Type: ExtractEvent, Key: "1Key", Old: 10}},
+	}, received, "Wrong notified events")
+
+	subscription.Unsubscribe()
+	dictionary.Add("4Key", 4)
+
+	assert.Len(test, received, 5, "Unsubscribed listeners shouldn't be notified")
+}
+
+func TestRangeOperationsAreBatched(test *testing.T) {
+	dictionary := NewEmptyDictionary()
+	received := [][]Event{}
+
+	dictionary.Subscribe(func(events []Event) {
+		received = append(received, events)
+	})
+
+	err := dictionary.AddRange([]KeyValueElement{{Key: "1Key", Value: 1}, {Key: "2Key", Value: 2}, {Key: "1Key", Value: 3}})
+
+	assert.ErrorIs(test, err, ErrDuplicatedKey, "Unexpected result adding duplicated keys")
+	assert.Empty(test, received, "Failed range operations shouldn't be notified")
+
+	dictionary.AddRange([]KeyValueElement{{Key: "1Key", Value: 1}, {Key: "2Key", Value: 2}})
+
+	assert.Equal(test, [][]Event{
+		{{Type: AddEvent, Key: "1Key", New: 1}, {Type: AddEvent, Key: "2Key", New: 2}},
+	}, received, "Range operations should be notified together")
+}
+
+func TestTypedListeners(test *testing.T) {
+	dictionary, _ := NewDictionary([]KeyValueElement{{Key: "1Key", Value: 1}})
+	events := map[EventType]int{}
+
+	count := func(event Event) { events[event.Type]++ }
+
+	dictionary.OnAdd(count)
+	dictionary.OnSet(count)
+	dictionary.OnDelete(count)
+	dictionary.OnExtract(count)
+
+	dictionary.Add("2Key", 2)
+	dictionary.Set("2Key", 20)
+	dictionary.Delete("2Key")
+	dictionary.Extract()
+
+	assert.Equal(test, map[EventType]int{AddEvent: 1, SetEvent: 1, DeleteEvent: 1, ExtractEvent: 1}, events, "Wrong events on typed listeners")
+}
+
+func TestResetEvent(test *testing.T) {
+	dictionary, _ := NewDictionary([]KeyValueElement{{Key: "1Key", Value: 1}})
+	resets := 0
+
+	dictionary.OnReset(func(Event) { resets++ })
+	dictionary.UnmarshalJSON([]byte(`{"2Key": 2}`))
+
+	assert.Equal(test, 1, resets, "Decoding should notify a single reset")
+}
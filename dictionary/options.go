@@ -0,0 +1,52 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// Package datatypes/dictionary provides an easy dictionary (key => value) homogeneous
+// struct management, making the iteration of a unique-key lists more powerful,
+// simple and clean, accepting primitives types and complex user structs as well.
+
+// This part of package contains the options of the range operations
+
+package dictionary
+
+import (
+	"reflect"
+
+	"github.com/jaimelopez/datatypes/internal/ranges"
+)
+
+// RangeOption configures the behaviour of a range operation
+type RangeOption = ranges.Option
+
+// JoinErrors makes the range operation check all the elements and return
+// every failure joined with errors.Join instead of stopping at the first one
+func JoinErrors() RangeOption {
+	return ranges.JoinErrors()
+}
+
+// checkElements checks if the key and the value of every element of a range have the
+// specified types and pass the check function, which returns the failure of an element or nil,
+// so a range operation can be validated before applying any change
+// The failures are collected as the options specify
+func checkElements(elements []KeyValueElement, keyDefinition reflect.Type, valueDefinition reflect.Type, check func(int, KeyValueElement) error, options []RangeOption) error {
+	failures := ranges.NewFailures(options)
+
+	for position, element := range elements {
+		var err error
+
+		if actual := reflect.TypeOf(element.Key); actual != keyDefinition {
+			err = &TypeMismatchError{Expected: keyDefinition, Actual: actual, IsKey: true, Index: position}
+		} else if actual := reflect.TypeOf(element.Value); actual != valueDefinition {
+			err = &TypeMismatchError{Expected: valueDefinition, Actual: actual, Index: position}
+		} else {
+			err = check(position, element)
+		}
+
+		if err != nil && !failures.Add(err) {
+			break
+		}
+	}
+
+	return failures.Err()
+}
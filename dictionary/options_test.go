@@ -0,0 +1,44 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// Package datatypes/dictionary provides an easy dictionary (key => value) homogeneous
+// struct management, making the iteration of a unique-key lists more powerful,
+// simple and clean, accepting primitives types and complex user structs as well.
+
+// This part of package contains the tests for the options of the range operations
+
+package dictionary
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRangeErrors(test *testing.T) {
+	dictionary, _ := NewDictionary([]KeyValueElement{{Key: "a", Value: 1}})
+
+	err := dictionary.AddRange([]KeyValueElement{{Key: "b", Value: 2}, {Key: 3, Value: 3}})
+
+	assert.Equal(test, &TypeMismatchError{Expected: reflect.TypeOf(""), Actual: reflect.TypeOf(0), IsKey: true, Index: 1}, err, "AddRange should return the first failure with its context")
+	assert.ErrorIs(test, err, ErrInvalidKeyValueElementType, "Type mismatches should match ErrInvalidKeyValueElementType")
+	assert.EqualError(test, err, "Element at index 1: Invalid key-value element type: dictionary must be homogeneous: expected key string but got int", "Wrong type mismatch message")
+	assert.ErrorIs(test, &DuplicateError{Key: "a"}, ErrDuplicatedKey, "Duplicates should match ErrDuplicatedKey")
+}
+
+func TestJoinErrorsOption(test *testing.T) {
+	dictionary, _ := NewDictionary([]KeyValueElement{{Key: "a", Value: 1}})
+
+	err := dictionary.AddRange([]KeyValueElement{{Key: "a", Value: 2}, {Key: "b", Value: "2"}, {Key: "c", Value: 3}, {Key: "c", Value: 4}}, JoinErrors())
+
+	assert.Equal(test, errors.Join(
+		&DuplicateError{Key: "a", Index: 0},
+		&TypeMismatchError{Expected: reflect.TypeOf(0), Actual: reflect.TypeOf(""), Index: 1},
+		&DuplicateError{Key: "c", Index: 3},
+	), err, "JoinErrors option should return all the failures of AddRange")
+	assert.Equal(test, KeyValueMap{"a": 1}, *dictionary.Elements(), "Failed AddRange calls should leave the dictionary untouched")
+	assert.Nil(test, dictionary.AddRange([]KeyValueElement{{Key: "b", Value: 2}}, JoinErrors()), "JoinErrors option shouldn't return errors without failures")
+}
@@ -0,0 +1,324 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// Package datatypes/dictionary provides an easy dictionary (key => value) homogeneous
+// struct management, making the iteration of a unique-key lists more powerful,
+// simple and clean, accepting primitives types and complex user structs as well.
+
+// This part of package contains the insertion-ordered dictionary
+
+package dictionary
+
+import (
+	"container/list"
+	"reflect"
+)
+
+// OrderedDictionary represents a dictionary (key => value) struct which
+// remembers the insertion order of the elements
+// Keys, values and elements are always returned in that order
+type OrderedDictionary struct {
+	keyDefinition   reflect.Type
+	valueDefinition reflect.Type
+	elements        map[KeyElement]*list.Element
+	order           *list.List
+}
+
+// Add a key-value element at the end of the dictionary
+// It follows the same rules than the Add method of Dictionary
+func (dic *OrderedDictionary) Add(key KeyElement, value ValueElement) error {
+	if dic.IsEmpty() {
+		dic.keyDefinition = reflect.TypeOf(key)
+		dic.valueDefinition = reflect.TypeOf(value)
+	} else if !dic.isHomogeneousWith(key, value) {
+		return ErrInvalidKeyValueElementType
+	}
+
+	if dic.Contains(key) {
+		return ErrDuplicatedKey
+	}
+
+	dic.elements[key] = dic.order.PushBack(&KeyValueElement{key, value})
+
+	return nil
+}
+
+// AddKeyValueElement adds an composed element KeyValueElement at the end of the dictionary
+func (dic *OrderedDictionary) AddKeyValueElement(element KeyValueElement) error {
+	return dic.Add(element.Key, element.Value)
+}
+
+// AddRange inserts a range (slice) of KeyValueElement at the end of the dictionary
+// It follows the same rules than the AddRange method of Dictionary
+func (dic *OrderedDictionary) AddRange(elements []KeyValueElement, options ...RangeOption) error {
+	if len(elements) == 0 {
+		return nil
+	}
+
+	keyDefinition, valueDefinition := dic.keyDefinition, dic.valueDefinition
+
+	if dic.IsEmpty() {
+		keyDefinition, valueDefinition = reflect.TypeOf(elements[0].Key), reflect.TypeOf(elements[0].Value)
+	}
+
+	staged := make(map[KeyElement]bool, len(elements))
+
+	err := checkElements(elements, keyDefinition, valueDefinition, func(position int, element KeyValueElement) error {
+		if dic.Contains(element.Key) || staged[element.Key] {
+			return &DuplicateError{Key: element.Key, Index: position}
+		}
+
+		staged[element.Key] = true
+
+		return nil
+	}, options)
+
+	if err != nil {
+		return err
+	}
+
+	for _, element := range elements {
+		dic.AddKeyValueElement(element)
+	}
+
+	return nil
+}
+
+// Element returns the specified key element in the dictionary
+func (dic *OrderedDictionary) Element(key KeyElement) (*ValueElement, error) {
+	node, exists := dic.elements[key]
+
+	if !exists {
+		return nil, ErrElementNotFound
+	}
+
+	value := node.Value.(*KeyValueElement).Value
+
+	return &value, nil
+}
+
+// Elements returns the stored elements in insertion order
+func (dic *OrderedDictionary) Elements() []KeyValueElement {
+	elements := []KeyValueElement{}
+
+	for node := dic.order.Front(); node != nil; node = node.Next() {
+		elements = append(elements, *node.Value.(*KeyValueElement))
+	}
+
+	return elements
+}
+
+// Keys returns all the keys in the dicionary in insertion order
+func (dic *OrderedDictionary) Keys() []KeyElement {
+	keys := []KeyElement{}
+
+	for node := dic.order.Front(); node != nil; node = node.Next() {
+		keys = append(keys, node.Value.(*KeyValueElement).Key)
+	}
+
+	return keys
+}
+
+// Values returns all the values in the dicionary in insertion order
+func (dic *OrderedDictionary) Values() []ValueElement {
+	values := []ValueElement{}
+
+	for node := dic.order.Front(); node != nil; node = node.Next() {
+		values = append(values, node.Value.(*KeyValueElement).Value)
+	}
+
+	return values
+}
+
+// KeyAt returns the key in the specified position following the insertion order
+func (dic *OrderedDictionary) KeyAt(position int) (KeyElement, error) {
+	node := dic.nodeAt(position)
+
+	if node == nil {
+		return nil, ErrIndexOutOfRange
+	}
+
+	return node.Value.(*KeyValueElement).Key, nil
+}
+
+// Extract the first inserted element and return it
+// If the dictionary is empty it returns nil
+// Keep in mind that this method will modify the dictionary elements subtracting that element
+func (dic *OrderedDictionary) Extract() *KeyValueElement {
+	if dic.IsEmpty() {
+		return nil
+	}
+
+	return dic.remove(dic.order.Front())
+}
+
+// ExtractLast extracts the last inserted element and return it
+// If the dictionary is empty it returns nil
+// Keep in mind that this method will modify the dictionary elements subtracting that element
+func (dic *OrderedDictionary) ExtractLast() *KeyValueElement {
+	if dic.IsEmpty() {
+		return nil
+	}
+
+	return dic.remove(dic.order.Back())
+}
+
+// ExtractKey extracts the specified key element and return it
+// Keep in mind that this method will modify the dictionary elements subtracting that element
+func (dic *OrderedDictionary) ExtractKey(key KeyElement) (*KeyValueElement, error) {
+	node, exists := dic.elements[key]
+
+	if !exists {
+		return nil, ErrElementNotFound
+	}
+
+	return dic.remove(node), nil
+}
+
+// Set a new value for a specified index element keeping its position
+func (dic *OrderedDictionary) Set(key KeyElement, value ValueElement) error {
+	if !dic.isHomogeneousWith(key, value) {
+		return ErrInvalidKeyValueElementType
+	}
+
+	node, exists := dic.elements[key]
+
+	if !exists {
+		return ErrElementNotFound
+	}
+
+	node.Value.(*KeyValueElement).Value = value
+
+	return nil
+}
+
+// Delete an specified already stored element
+// If it's not found the method will return an error
+func (dic *OrderedDictionary) Delete(key KeyElement) error {
+	_, err := dic.ExtractKey(key)
+
+	return err
+}
+
+// MoveToFront moves the specified key element to the first position
+func (dic *OrderedDictionary) MoveToFront(key KeyElement) error {
+	node, exists := dic.elements[key]
+
+	if !exists {
+		return ErrElementNotFound
+	}
+
+	dic.order.MoveToFront(node)
+
+	return nil
+}
+
+// MoveToBack moves the specified key element to the last position
+func (dic *OrderedDictionary) MoveToBack(key KeyElement) error {
+	node, exists := dic.elements[key]
+
+	if !exists {
+		return ErrElementNotFound
+	}
+
+	dic.order.MoveToBack(node)
+
+	return nil
+}
+
+// Contains checks if the specified key element is already existing in the dictionary
+func (dic *OrderedDictionary) Contains(key KeyElement) bool {
+	_, exists := dic.elements[key]
+
+	return exists
+}
+
+// ContainsValue checks if the specified value element exists in the dictionary
+func (dic *OrderedDictionary) ContainsValue(element ValueElement) bool {
+	for node := dic.order.Front(); node != nil; node = node.Next() {
+		if reflect.DeepEqual(node.Value.(*KeyValueElement).Value, element) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Filter returns the elements which satisfy the specified function in insertion order
+// If the functions return true the element will be filtered
+func (dic *OrderedDictionary) Filter(f func(KeyValueElement) bool) []KeyValueElement {
+	results := []KeyValueElement{}
+
+	for node := dic.order.Front(); node != nil; node = node.Next() {
+		if elem := *node.Value.(*KeyValueElement); f(elem) {
+			results = append(results, elem)
+		}
+	}
+
+	return results
+}
+
+// Size returns the number of elements inside the dicionary
+func (dic *OrderedDictionary) Size() int {
+	return len(dic.elements)
+}
+
+// IsEmpty checks if the dictionary is empty or not
+func (dic *OrderedDictionary) IsEmpty() bool {
+	return dic.Size() == 0
+}
+
+func (dic *OrderedDictionary) isHomogeneousWith(key KeyElement, value ValueElement) bool {
+	return dic.keyDefinition == reflect.TypeOf(key) &&
+		dic.valueDefinition == reflect.TypeOf(value)
+}
+
+func (dic *OrderedDictionary) remove(node *list.Element) *KeyValueElement {
+	element := dic.order.Remove(node).(*KeyValueElement)
+	delete(dic.elements, element.Key)
+
+	return &KeyValueElement{element.Key, element.Value}
+}
+
+// nodeAt walks the list from the nearest end to the specified position
+func (dic *OrderedDictionary) nodeAt(position int) *list.Element {
+	if position < 0 || position >= dic.Size() {
+		return nil
+	}
+
+	if position < dic.Size()/2 {
+		node := dic.order.Front()
+
+		for x := 0; x < position; x++ {
+			node = node.Next()
+		}
+
+		return node
+	}
+
+	node := dic.order.Back()
+
+	for x := dic.Size() - 1; x > position; x-- {
+		node = node.Prev()
+	}
+
+	return node
+}
+
+// NewEmptyOrderedDictionary instances a new empty ordered dictionary
+func NewEmptyOrderedDictionary() *OrderedDictionary {
+	return &OrderedDictionary{
+		elements: make(map[KeyElement]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// NewOrderedDictionary allows to instance a new OrderedDictionary with a group of key-value elements
+// The elements keep the order of the specified slice
+func NewOrderedDictionary(elements []KeyValueElement) (*OrderedDictionary, error) {
+	dictionary := NewEmptyOrderedDictionary()
+	err := dictionary.AddRange(elements)
+
+	return dictionary, err
+}
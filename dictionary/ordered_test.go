@@ -0,0 +1,115 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// Package datatypes/dictionary provides an easy dictionary (key => value) homogeneous
+// struct management, making the iteration of a unique-key lists more powerful,
+// simple and clean, accepting primitives types and complex user structs as well.
+
+// This part of package contains the tests for the insertion-ordered dictionary
+
+package dictionary
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func orderedElements() []KeyValueElement {
+	return []KeyValueElement{
+		{"cKey", "cValue"},
+		{"aKey", "aValue"},
+		{"bKey", "bValue"},
+	}
+}
+
+func TestOrderedAddMethod(test *testing.T) {
+	dictionary := NewEmptyOrderedDictionary()
+
+	assert.Nil(test, dictionary.Add("key", "value"), "Unexpected error adding an ordered element")
+	assert.Equal(test, ErrDuplicatedKey, dictionary.Add("key", "value"), "Duplicated keys should return an error on ordered Add method")
+	assert.Equal(test, ErrInvalidKeyValueElementType, dictionary.Add(1, 2), "Non-homogeneous elements should return an error on ordered Add method")
+}
+
+func TestOrderedAddRangeMethod(test *testing.T) {
+	dictionary, _ := NewOrderedDictionary(orderedElements())
+
+	assert.Equal(test, &DuplicateError{Key: "aKey", Index: 1}, dictionary.AddRange([]KeyValueElement{{"dKey", "dValue"}, {"aKey", "aValue"}}), "Duplicated keys in range should return an error")
+	assert.Equal(test, orderedElements(), dictionary.Elements(), "Failed AddRange calls should leave the ordered dictionary untouched")
+
+	err := dictionary.AddRange([]KeyValueElement{{"dKey", 1}, {"dKey", "dValue"}, {"dKey", "dValue"}}, JoinErrors())
+
+	assert.Equal(test, errors.Join(
+		&TypeMismatchError{Expected: reflect.TypeOf(""), Actual: reflect.TypeOf(0), Index: 0},
+		&DuplicateError{Key: "dKey", Index: 2},
+	), err, "JoinErrors option should return all the failures of ordered AddRange")
+	assert.Equal(test, orderedElements(), dictionary.Elements(), "Failed AddRange calls should leave the ordered dictionary untouched")
+}
+
+func TestOrderedIterationMethods(test *testing.T) {
+	dictionary, _ := NewOrderedDictionary(orderedElements())
+
+	assert.Equal(test, orderedElements(), dictionary.Elements(), "Elements should be returned in insertion order")
+	assert.Equal(test, []KeyElement{"cKey", "aKey", "bKey"}, dictionary.Keys(), "Keys should be returned in insertion order")
+	assert.Equal(test, []ValueElement{"cValue", "aValue", "bValue"}, dictionary.Values(), "Values should be returned in insertion order")
+
+	matches := dictionary.Filter(func(elem KeyValueElement) bool { return elem.Key != "aKey" })
+
+	assert.Equal(test, []KeyValueElement{{"cKey", "cValue"}, {"bKey", "bValue"}}, matches, "Filtered elements should keep the insertion order")
+}
+
+func TestOrderedExtractMethods(test *testing.T) {
+	dictionary, _ := NewOrderedDictionary(orderedElements())
+
+	assert.Equal(test, KeyValueElement{"cKey", "cValue"}, *dictionary.Extract(), "Extract should return the first inserted element")
+	assert.Equal(test, KeyValueElement{"bKey", "bValue"}, *dictionary.ExtractLast(), "ExtractLast should return the last inserted element")
+
+	extracted, err := dictionary.ExtractKey("aKey")
+
+	assert.Nil(test, err, "Unexpected error on ordered ExtractKey method")
+	assert.Equal(test, KeyValueElement{"aKey", "aValue"}, *extracted, "Wrong extracted element on ordered ExtractKey method")
+	assert.Nil(test, dictionary.Extract(), "Extracting from an empty ordered dictionary should return nil")
+	assert.Nil(test, dictionary.ExtractLast(), "Extracting from an empty ordered dictionary should return nil")
+}
+
+func TestOrderedSetAndDeleteMethods(test *testing.T) {
+	dictionary, _ := NewOrderedDictionary(orderedElements())
+
+	assert.Nil(test, dictionary.Set("aKey", "newValue"), "Unexpected error on ordered Set method")
+	assert.Equal(test, []ValueElement{"cValue", "newValue", "bValue"}, dictionary.Values(), "Set method should keep the element position")
+
+	value, _ := dictionary.Element("aKey")
+
+	assert.Equal(test, "newValue", *value, "Wrong returned value after ordered Set method")
+	assert.Nil(test, dictionary.Delete("cKey"), "Unexpected error on ordered Delete method")
+	assert.Equal(test, ErrElementNotFound, dictionary.Delete("cKey"), "Not found keys should return an error on ordered Delete method")
+	assert.Equal(test, []KeyElement{"aKey", "bKey"}, dictionary.Keys(), "Wrong remained keys after ordered Delete method")
+	assert.True(test, dictionary.ContainsValue("bValue"), "ContainsValue return a false negative on ordered dictionary")
+}
+
+func TestOrderedMoveMethods(test *testing.T) {
+	dictionary, _ := NewOrderedDictionary(orderedElements())
+
+	assert.Nil(test, dictionary.MoveToFront("bKey"), "Unexpected error on MoveToFront method")
+	assert.Nil(test, dictionary.MoveToBack("cKey"), "Unexpected error on MoveToBack method")
+	assert.Equal(test, []KeyElement{"bKey", "aKey", "cKey"}, dictionary.Keys(), "Wrong order after moving elements")
+	assert.Equal(test, ErrElementNotFound, dictionary.MoveToFront("dKey"), "Not found keys should return an error on MoveToFront method")
+}
+
+func TestOrderedKeyAtMethod(test *testing.T) {
+	dictionary, _ := NewOrderedDictionary(orderedElements())
+
+	for position, element := range orderedElements() {
+		key, err := dictionary.KeyAt(position)
+
+		assert.Nil(test, err, "Unexpected error on KeyAt method")
+		assert.Equal(test, element.Key, key, "Wrong key returned on KeyAt method")
+	}
+
+	_, err := dictionary.KeyAt(3)
+
+	assert.Equal(test, ErrIndexOutOfRange, err, "Out of range positions should return an error on KeyAt method")
+}
@@ -0,0 +1,316 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// Package datatypes/dictionary provides an easy dictionary (key => value) homogeneous
+// struct management, making the iteration of a unique-key lists more powerful,
+// simple and clean, accepting primitives types and complex user structs as well.
+
+// This part of package contains the persistent immutable dictionary
+
+package dictionary
+
+import (
+	"iter"
+	"reflect"
+
+	"github.com/jaimelopez/datatypes/internal/hamt"
+)
+
+// PersistentDictionary represents an immutable dictionary
+// Modifications return new versions in O(log n) which share most of their
+// structure with the original, so keeping old versions around is cheap
+type PersistentDictionary struct {
+	keyDefinition   reflect.Type
+	valueDefinition reflect.Type
+	elements        *hamt.Map
+}
+
+// With returns a new version of the dictionary storing the value under the specified key
+// The key is added if it doesn't exist yet, otherwise its value is replaced.
+// The dictionary must be homogeneous following the same rules than Dictionary
+func (dic *PersistentDictionary) With(key KeyElement, value ValueElement) (*PersistentDictionary, error) {
+	if !dic.IsEmpty() && !dic.isHomogeneousWith(key, value) {
+		return nil, ErrInvalidKeyValueElementType
+	}
+
+	return &PersistentDictionary{
+		keyDefinition:   reflect.TypeOf(key),
+		valueDefinition: reflect.TypeOf(value),
+		elements:        dic.elements.Set(key, value),
+	}, nil
+}
+
+// Without returns a new version of the dictionary without the specified key
+// If the key doesn't exist the same dictionary is returned
+func (dic *PersistentDictionary) Without(key KeyElement) *PersistentDictionary {
+	if !dic.Contains(key) {
+		return dic
+	}
+
+	return &PersistentDictionary{
+		keyDefinition:   dic.keyDefinition,
+		valueDefinition: dic.valueDefinition,
+		elements:        dic.elements.Delete(key),
+	}
+}
+
+// Element returns the specified key element in the dictionary
+func (dic *PersistentDictionary) Element(key KeyElement) (*ValueElement, error) {
+	if !dic.Contains(key) {
+		return nil, ErrElementNotFound
+	}
+
+	value, _ := dic.elements.Get(key)
+	element := ValueElement(value)
+
+	return &element, nil
+}
+
+// Elements returns a map with all the stored elements
+func (dic *PersistentDictionary) Elements() *KeyValueMap {
+	elements := make(KeyValueMap, dic.Size())
+
+	for key, value := range dic.Iter() {
+		elements[key] = value
+	}
+
+	return &elements
+}
+
+// Keys returns all the stored keys
+func (dic *PersistentDictionary) Keys() []KeyElement {
+	keys := make([]KeyElement, 0, dic.Size())
+
+	for key := range dic.Iter() {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+// Values returns all the stored values
+func (dic *PersistentDictionary) Values() []ValueElement {
+	values := make([]ValueElement, 0, dic.Size())
+
+	for _, value := range dic.Iter() {
+		values = append(values, value)
+	}
+
+	return values
+}
+
+// Iter returns an iterator over the keys and values of the dictionary
+func (dic *PersistentDictionary) Iter() iter.Seq2[KeyElement, ValueElement] {
+	return func(yield func(KeyElement, ValueElement) bool) {
+		dic.elements.Range(func(key interface{}, value interface{}) bool {
+			return yield(key, value)
+		})
+	}
+}
+
+// Contains checks if the specified key exists in the dictionary
+func (dic *PersistentDictionary) Contains(key KeyElement) bool {
+	if dic.IsEmpty() || dic.keyDefinition != reflect.TypeOf(key) {
+		return false
+	}
+
+	_, exists := dic.elements.Get(key)
+
+	return exists
+}
+
+// Size returns the number of stored elements
+func (dic *PersistentDictionary) Size() int {
+	return dic.elements.Len()
+}
+
+// IsEmpty checks if the dictionary is empty or not
+func (dic *PersistentDictionary) IsEmpty() bool {
+	return dic.Size() == 0
+}
+
+// Dictionary returns a mutable dictionary with the same elements
+func (dic *PersistentDictionary) Dictionary() *Dictionary {
+	dictionary := NewEmptyDictionary()
+	dictionary.keyDefinition = dic.keyDefinition
+	dictionary.valueDefinition = dic.valueDefinition
+
+	for key, value := range dic.Iter() {
+		dictionary.elements[key] = value
+	}
+
+	return dictionary
+}
+
+// Transient returns a mutable builder initialized with the elements of the dictionary
+// intended for bulk modifications, which is much faster than creating a new
+// version for every change. The dictionary itself is never modified
+func (dic *PersistentDictionary) Transient() *TransientDictionary {
+	return &TransientDictionary{
+		keyDefinition:   dic.keyDefinition,
+		valueDefinition: dic.valueDefinition,
+		elements:        dic.elements.Transient(),
+	}
+}
+
+func (dic *PersistentDictionary) isHomogeneousWith(key KeyElement, value ValueElement) bool {
+	return dic.keyDefinition == reflect.TypeOf(key) && dic.valueDefinition == reflect.TypeOf(value)
+}
+
+// TransientDictionary represents a mutable builder of persistent dictionaries
+// It modifies in place the structure it owns, so it must not be used concurrently
+type TransientDictionary struct {
+	keyDefinition   reflect.Type
+	valueDefinition reflect.Type
+	elements        *hamt.Transient
+}
+
+// Add a key-value element to the dictionary
+// It follows the same rules than the Add method of Dictionary
+func (dic *TransientDictionary) Add(key KeyElement, value ValueElement) error {
+	if dic.elements.Len() == 0 {
+		dic.keyDefinition = reflect.TypeOf(key)
+		dic.valueDefinition = reflect.TypeOf(value)
+	} else if dic.keyDefinition != reflect.TypeOf(key) || dic.valueDefinition != reflect.TypeOf(value) {
+		return ErrInvalidKeyValueElementType
+	}
+
+	if dic.Contains(key) {
+		return ErrDuplicatedKey
+	}
+
+	dic.elements.Set(key, value)
+
+	return nil
+}
+
+// AddRange inserts a range (slice) of KeyValueElement inside the dictionary
+// It follows the same rules than the AddRange method of Dictionary
+func (dic *TransientDictionary) AddRange(elements []KeyValueElement, options ...RangeOption) error {
+	if len(elements) == 0 {
+		return nil
+	}
+
+	keyDefinition, valueDefinition := dic.keyDefinition, dic.valueDefinition
+
+	if dic.elements.Len() == 0 {
+		keyDefinition, valueDefinition = reflect.TypeOf(elements[0].Key), reflect.TypeOf(elements[0].Value)
+	}
+
+	staged := make(map[KeyElement]bool, len(elements))
+
+	err := checkElements(elements, keyDefinition, valueDefinition, func(position int, element KeyValueElement) error {
+		if dic.Contains(element.Key) || staged[element.Key] {
+			return &DuplicateError{Key: element.Key, Index: position}
+		}
+
+		staged[element.Key] = true
+
+		return nil
+	}, options)
+
+	if err != nil {
+		return err
+	}
+
+	for _, element := range elements {
+		dic.Add(element.Key, element.Value)
+	}
+
+	return nil
+}
+
+// Set sets the value of an already existing key
+// It follows the same rules than the Set method of Dictionary
+func (dic *TransientDictionary) Set(key KeyElement, value ValueElement) error {
+	if !dic.Contains(key) {
+		return ErrElementNotFound
+	}
+
+	if dic.valueDefinition != reflect.TypeOf(value) {
+		return ErrInvalidKeyValueElementType
+	}
+
+	dic.elements.Set(key, value)
+
+	return nil
+}
+
+// Delete removes the specified key from the dictionary
+// If the key doesn't exist it returns an error
+func (dic *TransientDictionary) Delete(key KeyElement) error {
+	if !dic.Contains(key) {
+		return ErrElementNotFound
+	}
+
+	dic.elements.Delete(key)
+
+	return nil
+}
+
+// Contains checks if the specified key exists in the dictionary
+func (dic *TransientDictionary) Contains(key KeyElement) bool {
+	if dic.elements.Len() == 0 || dic.keyDefinition != reflect.TypeOf(key) {
+		return false
+	}
+
+	_, exists := dic.elements.Get(key)
+
+	return exists
+}
+
+// Size returns the number of stored elements
+func (dic *TransientDictionary) Size() int {
+	return dic.elements.Len()
+}
+
+// Persistent returns an immutable dictionary with the current elements
+// The builder can still be used afterwards without affecting the returned dictionary
+func (dic *TransientDictionary) Persistent() *PersistentDictionary {
+	return &PersistentDictionary{
+		keyDefinition:   dic.keyDefinition,
+		valueDefinition: dic.valueDefinition,
+		elements:        dic.elements.Persistent(),
+	}
+}
+
+// Persistent returns an immutable copy of the dictionary
+func (dic *Dictionary) Persistent() *PersistentDictionary {
+	transient := NewEmptyPersistentDictionary().Transient()
+	transient.keyDefinition = dic.keyDefinition
+	transient.valueDefinition = dic.valueDefinition
+
+	for key, value := range dic.elements {
+		transient.elements.Set(key, value)
+	}
+
+	return transient.Persistent()
+}
+
+// NewEmptyPersistentDictionary instances a new empty persistent dictionary
+func NewEmptyPersistentDictionary() *PersistentDictionary {
+	return &PersistentDictionary{elements: hamt.New(hashKey, equalKeys)}
+}
+
+// NewPersistentDictionary instances a new persistent dictionary with the specified elements
+// It follows the same rules than NewDictionary
+func NewPersistentDictionary(elements []KeyValueElement) (*PersistentDictionary, error) {
+	transient := NewEmptyPersistentDictionary().Transient()
+
+	if err := transient.AddRange(elements); err != nil {
+		return nil, err
+	}
+
+	return transient.Persistent(), nil
+}
+
+// hashKey hashes the keys as the sharded dictionary does, consistently with equalKeys,
+// so pointers are hashed by identity
+func hashKey(key interface{}) uint64 {
+	return keyHash(key)
+}
+
+func equalKeys(first interface{}, second interface{}) bool {
+	return first == second
+}
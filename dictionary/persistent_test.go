@@ -0,0 +1,156 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// Package datatypes/dictionary provides an easy dictionary (key => value) homogeneous
+// struct management, making the iteration of a unique-key lists more powerful,
+// simple and clean, accepting primitives types and complex user structs as well.
+
+// This part of package contains the tests for the persistent immutable dictionary
+
+package dictionary
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPersistentWithMethod(test *testing.T) {
+	empty := NewEmptyPersistentDictionary()
+	first, err := empty.With("1Key", 1)
+
+	assert.Nil(test, err, "Unexpected error on With method")
+
+	second, _ := first.With("2Key", 2)
+	replaced, _ := second.With("1Key", 10)
+
+	assert.Equal(test, KeyValueMap{"1Key": 10, "2Key": 2}, *replaced.Elements(), "Wrong elements on With method")
+	assert.Equal(test, KeyValueMap{"1Key": 1, "2Key": 2}, *second.Elements(), "Previous versions shouldn't be modified")
+	assert.Equal(test, KeyValueMap{"1Key": 1}, *first.Elements(), "Previous versions shouldn't be modified")
+	assert.True(test, empty.IsEmpty(), "Previous versions shouldn't be modified")
+
+	_, err = second.With(1, 1)
+
+	assert.Equal(test, ErrInvalidKeyValueElementType, err, "Heterogeneous keys should return an error")
+
+	_, err = second.With("3Key", "3")
+
+	assert.Equal(test, ErrInvalidKeyValueElementType, err, "Heterogeneous values should return an error")
+}
+
+func TestPersistentWithoutMethod(test *testing.T) {
+	original, _ := NewPersistentDictionary([]KeyValueElement{{Key: "1Key", Value: 1}, {Key: "2Key", Value: 2}})
+	deleted := original.Without("1Key")
+
+	assert.Equal(test, KeyValueMap{"2Key": 2}, *deleted.Elements(), "Wrong elements on Without method")
+	assert.Equal(test, 2, original.Size(), "Previous versions shouldn't be modified")
+	assert.Same(test, deleted, deleted.Without("1Key"), "Removing non existing keys should return the same dictionary")
+}
+
+func TestPersistentElementMethod(test *testing.T) {
+	dictionary, _ := NewPersistentDictionary([]KeyValueElement{{Key: "1Key", Value: 1}})
+
+	value, err := dictionary.Element("1Key")
+
+	assert.Nil(test, err, "Unexpected error on Element method")
+	assert.Equal(test, 1, *value, "Wrong value on Element method")
+
+	_, err = dictionary.Element("2Key")
+
+	assert.Equal(test, ErrElementNotFound, err, "Non existing keys should return an error")
+	assert.ElementsMatch(test, []KeyElement{"1Key"}, dictionary.Keys(), "Wrong keys")
+	assert.ElementsMatch(test, []ValueElement{1}, dictionary.Values(), "Wrong values")
+}
+
+func TestTransientDictionary(test *testing.T) {
+	original, _ := NewPersistentDictionary([]KeyValueElement{{Key: 0, Value: "0"}})
+	transient := original.Transient()
+
+	for number := 1; number < 100; number++ {
+		assert.Nil(test, transient.Add(number, "n"), "Unexpected error adding to a transient dictionary")
+	}
+
+	assert.Equal(test, ErrDuplicatedKey, transient.Add(1, "n"), "Duplicated keys should return an error")
+	assert.Nil(test, transient.Set(1, "one"), "Unexpected error setting a transient element")
+	assert.Equal(test, ErrElementNotFound, transient.Set(100, "n"), "Setting non existing keys should return an error")
+	assert.Nil(test, transient.Delete(0), "Unexpected error deleting a transient element")
+	assert.Equal(test, ErrElementNotFound, transient.Delete(0), "Deleting non existing keys should return an error")
+
+	persistent := transient.Persistent()
+	transient.Delete(1)
+
+	value, _ := persistent.Element(1)
+
+	assert.Equal(test, "one", *value, "Transients shouldn't modify the persisted dictionaries")
+	assert.Equal(test, 99, persistent.Size(), "Wrong size of the persisted dictionary")
+	assert.Equal(test, 98, transient.Size(), "Transients should be usable after being persisted")
+	assert.Equal(test, KeyValueMap{0: "0"}, *original.Elements(), "Transients shouldn't modify the original dictionary")
+
+	err := transient.AddRange([]KeyValueElement{{100, "n"}, {100, "n"}, {2, "n"}, {101, 1}}, JoinErrors())
+
+	assert.Equal(test, errors.Join(
+		&DuplicateError{Key: 100, Index: 1},
+		&DuplicateError{Key: 2, Index: 2},
+		&TypeMismatchError{Expected: reflect.TypeOf(""), Actual: reflect.TypeOf(0), Index: 3},
+	), err, "JoinErrors option should return all the failures of transient AddRange")
+	assert.Equal(test, 98, transient.Size(), "Failed AddRange calls should leave the transient dictionary untouched")
+}
+
+func TestPersistentConversions(test *testing.T) {
+	dictionary, _ := NewDictionary([]KeyValueElement{{Key: "1Key", Value: 1}, {Key: "2Key", Value: 2}})
+	persistent := dictionary.Persistent()
+
+	assert.Equal(test, dictionary.Elements(), persistent.Elements(), "Wrong elements converting to a persistent dictionary")
+	assert.Equal(test, dictionary.Elements(), persistent.Dictionary().Elements(), "Wrong elements converting from a persistent dictionary")
+
+	next, _ := persistent.With("3Key", 3)
+
+	assert.Equal(test, 2, dictionary.Size(), "Persistent copies shouldn't modify the original dictionary")
+	assert.Equal(test, 3, next.Size(), "Wrong size after modifying a persistent copy")
+}
+
+func BenchmarkPersistentWith(benchmark *testing.B) {
+	dictionary := NewEmptyPersistentDictionary()
+
+	for x := 0; x < benchmark.N; x++ {
+		dictionary, _ = dictionary.With(x%10000, x)
+	}
+}
+
+func BenchmarkCloneAndSet(benchmark *testing.B) {
+	dictionary := NewEmptyDictionary()
+
+	for x := 0; x < 10000; x++ {
+		dictionary.Add(x, x)
+	}
+
+	benchmark.ResetTimer()
+
+	for x := 0; x < benchmark.N; x++ {
+		dictionary = dictionary.clone()
+		dictionary.Set(x%10000, x)
+	}
+}
+
+func TestPersistentMutatedPointerKeys(test *testing.T) {
+	type key struct {
+		Name   string
+		Parent *[]string
+	}
+
+	parent := []string{"a"}
+	pointer := &parent
+	dictionary, _ := NewPersistentDictionary([]KeyValueElement{{Key: key{"b", pointer}, Value: 1}})
+
+	parent[0] = "mutated"
+
+	assert.True(test, dictionary.Contains(key{"b", pointer}), "Mutating the value pointed by a key field shouldn't lose it")
+
+	value, err := dictionary.Element(key{"b", pointer})
+
+	assert.Nil(test, err, "Unexpected error looking up a mutated pointer key")
+	assert.Equal(test, 1, *value, "Wrong value of a mutated pointer key")
+}
@@ -0,0 +1,360 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// Package datatypes/dictionary provides an easy dictionary (key => value) homogeneous
+// struct management, making the iteration of a unique-key lists more powerful,
+// simple and clean, accepting primitives types and complex user structs as well.
+
+// This part of package contains the sharded thread-safe dictionary
+
+package dictionary
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+
+	"github.com/jaimelopez/datatypes/generic"
+)
+
+// DefaultShards is the number of shards used when it's not specified
+const DefaultShards = 32
+
+// ShardedDictionary represents a dictionary safe for concurrent use by multiple goroutines
+// which partitions the keys across several internally locked shards by their hash,
+// so operations over keys stored in different shards don't block each other
+// Unlike Dictionary, the type definition is taken from the first added element
+// and it's kept even if the dictionary becomes empty
+type ShardedDictionary struct {
+	definition atomic.Pointer[shardedDefinition]
+	shards     []*shard
+}
+
+type shardedDefinition struct {
+	key   reflect.Type
+	value reflect.Type
+}
+
+type shard struct {
+	sync.RWMutex
+	elements KeyValueMap
+}
+
+// Add a key-value element to the dictionary
+// If the key is already stored or the element is not homogeneous with the rest it returns an error
+func (dic *ShardedDictionary) Add(key KeyElement, value ValueElement) error {
+	if err := dic.define(key, value); err != nil {
+		return err
+	}
+
+	shard := dic.shardOf(key)
+
+	shard.Lock()
+	defer shard.Unlock()
+
+	if _, exists := shard.elements[key]; exists {
+		return ErrDuplicatedKey
+	}
+
+	shard.elements[key] = value
+
+	return nil
+}
+
+// AddKeyValueElement adds an composed element KeyValueElement to the dictionary
+func (dic *ShardedDictionary) AddKeyValueElement(element KeyValueElement) error {
+	return dic.Add(element.Key, element.Value)
+}
+
+// AddRange inserts a range (slice) of KeyValueElement inside the dictionary
+// It follows the same rules than the AddRange method of Dictionary.
+// All the shards are locked while the range is checked and added,
+// so the rest of goroutines see the whole range or none of it
+func (dic *ShardedDictionary) AddRange(elements []KeyValueElement, options ...RangeOption) error {
+	if len(elements) == 0 {
+		return nil
+	}
+
+	for _, shard := range dic.shards {
+		shard.Lock()
+		defer shard.Unlock()
+	}
+
+	for {
+		definition := dic.definition.Load()
+		undefined := definition == nil
+
+		if undefined {
+			definition = &shardedDefinition{reflect.TypeOf(elements[0].Key), reflect.TypeOf(elements[0].Value)}
+		}
+
+		if err := dic.checkAdditions(elements, definition, options); err != nil {
+			return err
+		}
+
+		// The definition could be set by a concurrent Add, which doesn't lock all the shards
+		if !undefined || dic.definition.CompareAndSwap(nil, definition) {
+			break
+		}
+	}
+
+	for _, element := range elements {
+		dic.shardOf(element.Key).elements[element.Key] = element.Value
+	}
+
+	return nil
+}
+
+// Element returns the specified key element in the dictionary
+func (dic *ShardedDictionary) Element(key KeyElement) (*ValueElement, error) {
+	shard := dic.shardOf(key)
+
+	shard.RLock()
+	defer shard.RUnlock()
+
+	element, exists := shard.elements[key]
+
+	if !exists {
+		return nil, ErrElementNotFound
+	}
+
+	return &element, nil
+}
+
+// Elements returns a consistent snapshot of all the stored elements
+func (dic *ShardedDictionary) Elements() *KeyValueMap {
+	elements := make(KeyValueMap)
+
+	dic.snapshot(func(key KeyElement, value ValueElement) {
+		elements[key] = value
+	})
+
+	return &elements
+}
+
+// Keys returns a consistent snapshot of all the keys in the dicionary
+func (dic *ShardedDictionary) Keys() []KeyElement {
+	keys := []KeyElement{}
+
+	dic.snapshot(func(key KeyElement, _ ValueElement) {
+		keys = append(keys, key)
+	})
+
+	return keys
+}
+
+// Values returns a consistent snapshot of all the values in the dicionary
+func (dic *ShardedDictionary) Values() []ValueElement {
+	values := []ValueElement{}
+
+	dic.snapshot(func(_ KeyElement, value ValueElement) {
+		values = append(values, value)
+	})
+
+	return values
+}
+
+// Set a new value for a specified index element
+func (dic *ShardedDictionary) Set(key KeyElement, value ValueElement) error {
+	if !dic.isHomogeneousWith(key, value) {
+		return ErrInvalidKeyValueElementType
+	}
+
+	shard := dic.shardOf(key)
+
+	shard.Lock()
+	defer shard.Unlock()
+
+	if _, exists := shard.elements[key]; !exists {
+		return ErrElementNotFound
+	}
+
+	shard.elements[key] = value
+
+	return nil
+}
+
+// Delete an specified already stored element
+// If it's not found the method will return an error
+func (dic *ShardedDictionary) Delete(key KeyElement) error {
+	shard := dic.shardOf(key)
+
+	shard.Lock()
+	defer shard.Unlock()
+
+	if _, exists := shard.elements[key]; !exists {
+		return ErrElementNotFound
+	}
+
+	delete(shard.elements, key)
+
+	return nil
+}
+
+// Contains checks if the specified key element is already existing in the dictionary
+func (dic *ShardedDictionary) Contains(key KeyElement) bool {
+	shard := dic.shardOf(key)
+
+	shard.RLock()
+	defer shard.RUnlock()
+
+	_, exists := shard.elements[key]
+
+	return exists
+}
+
+// Range calls the specified function for every stored element
+// Shards are walked in parallel, so the function must be safe for concurrent use
+// and it must not modify the dictionary
+// If the function returns false the iteration stops
+func (dic *ShardedDictionary) Range(f func(KeyElement, ValueElement) bool) {
+	var group sync.WaitGroup
+	var stopped int32
+
+	for _, current := range dic.shards {
+		group.Add(1)
+
+		go func(shard *shard) {
+			defer group.Done()
+
+			shard.RLock()
+			defer shard.RUnlock()
+
+			for key, value := range shard.elements {
+				if atomic.LoadInt32(&stopped) == 1 {
+					return
+				}
+
+				if !f(key, value) {
+					atomic.StoreInt32(&stopped, 1)
+
+					return
+				}
+			}
+		}(current)
+	}
+
+	group.Wait()
+}
+
+// Size returns the number of elements inside the dicionary
+func (dic *ShardedDictionary) Size() int {
+	size := 0
+
+	for _, shard := range dic.shards {
+		shard.RLock()
+		size += len(shard.elements)
+		shard.RUnlock()
+	}
+
+	return size
+}
+
+// IsEmpty checks if the dictionary is empty or not
+func (dic *ShardedDictionary) IsEmpty() bool {
+	return dic.Size() == 0
+}
+
+// define takes the type definition of the dictionary from the first added element
+// and checks the homogeneity of the following ones
+func (dic *ShardedDictionary) define(key KeyElement, value ValueElement) error {
+	if dic.definition.Load() == nil {
+		dic.definition.CompareAndSwap(nil, &shardedDefinition{reflect.TypeOf(key), reflect.TypeOf(value)})
+	}
+
+	if !dic.isHomogeneousWith(key, value) {
+		return ErrInvalidKeyValueElementType
+	}
+
+	return nil
+}
+
+func (dic *ShardedDictionary) isHomogeneousWith(key KeyElement, value ValueElement) bool {
+	definition := dic.definition.Load()
+
+	return definition != nil &&
+		definition.key == reflect.TypeOf(key) &&
+		definition.value == reflect.TypeOf(value)
+}
+
+// checkAdditions checks if all the elements could be added to the dictionary
+// with the specified definition, so the shards must be already locked
+func (dic *ShardedDictionary) checkAdditions(elements []KeyValueElement, definition *shardedDefinition, options []RangeOption) error {
+	staged := make(map[KeyElement]bool, len(elements))
+
+	return checkElements(elements, definition.key, definition.value, func(position int, element KeyValueElement) error {
+		if _, exists := dic.shardOf(element.Key).elements[element.Key]; exists || staged[element.Key] {
+			return &DuplicateError{Key: element.Key, Index: position}
+		}
+
+		staged[element.Key] = true
+
+		return nil
+	}, options)
+}
+
+func (dic *ShardedDictionary) shardOf(key KeyElement) *shard {
+	return dic.shards[keyHash(key)%uint64(len(dic.shards))]
+}
+
+// keyHash avoids the reflection based hashing for the most common key types
+// Any other key is hashed with generic.HashKey, which agrees with the == operator
+// used by the maps since it hashes pointers by identity, so mutating the
+// value pointed by a key doesn't move it to another shard
+func keyHash(key KeyElement) uint64 {
+	switch value := key.(type) {
+	case string:
+		hash := uint64(14695981039346656037)
+
+		for x := 0; x < len(value); x++ {
+			hash ^= uint64(value[x])
+			hash *= 1099511628211
+		}
+
+		return hash
+	case int:
+		return uint64(value) * 11400714819323198485
+	}
+
+	return generic.HashKey(key)
+}
+
+// snapshot locks all the shards at once, so none of them can change
+// while the function is called for every stored element
+func (dic *ShardedDictionary) snapshot(f func(KeyElement, ValueElement)) {
+	for _, shard := range dic.shards {
+		shard.RLock()
+		defer shard.RUnlock()
+	}
+
+	for _, shard := range dic.shards {
+		for key, value := range shard.elements {
+			f(key, value)
+		}
+	}
+}
+
+// NewEmptyShardedDictionary instances a new empty sharded dictionary with the specified
+// number of shards, or DefaultShards if it's not a positive number
+func NewEmptyShardedDictionary(shards int) *ShardedDictionary {
+	if shards <= 0 {
+		shards = DefaultShards
+	}
+
+	dic := &ShardedDictionary{shards: make([]*shard, shards)}
+
+	for x := range dic.shards {
+		dic.shards[x] = &shard{elements: make(KeyValueMap)}
+	}
+
+	return dic
+}
+
+// NewShardedDictionary allows to instance a new sharded dictionary with a group of key-value elements
+func NewShardedDictionary(shards int, elements []KeyValueElement) (*ShardedDictionary, error) {
+	dictionary := NewEmptyShardedDictionary(shards)
+	err := dictionary.AddRange(elements)
+
+	return dictionary, err
+}
@@ -0,0 +1,206 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// Package datatypes/dictionary provides an easy dictionary (key => value) homogeneous
+// struct management, making the iteration of a unique-key lists more powerful,
+// simple and clean, accepting primitives types and complex user structs as well.
+
+// This part of package contains the tests and benchmarks for the sharded dictionary
+
+package dictionary
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShardedAddMethod(test *testing.T) {
+	dictionary := NewEmptyShardedDictionary(4)
+
+	assert.Nil(test, dictionary.Add("key", "value"), "Unexpected error adding a sharded element")
+	assert.Equal(test, ErrDuplicatedKey, dictionary.Add("key", "value"), "Duplicated keys should return an error on sharded Add method")
+	assert.Equal(test, ErrInvalidKeyValueElementType, dictionary.Add(1, 2), "Non-homogeneous elements should return an error on sharded Add method")
+	assert.Equal(test, 1, dictionary.Size(), "Wrong size after adding sharded elements")
+}
+
+func TestShardedAddRangeMethod(test *testing.T) {
+	dictionary, _ := NewShardedDictionary(4, []KeyValueElement{{"1Key", "1Value"}})
+
+	assert.Equal(test, &DuplicateError{Key: "1Key", Index: 1}, dictionary.AddRange([]KeyValueElement{{"2Key", "2Value"}, {"1Key", "1Value"}}), "Duplicated keys in range should return an error")
+	assert.Equal(test, 1, dictionary.Size(), "Failed AddRange calls should leave the sharded dictionary untouched")
+
+	err := dictionary.AddRange([]KeyValueElement{{"2Key", "2Value"}, {"2Key", "2Value"}, {"3Key", 3}}, JoinErrors())
+
+	assert.Equal(test, errors.Join(
+		&DuplicateError{Key: "2Key", Index: 1},
+		&TypeMismatchError{Expected: reflect.TypeOf(""), Actual: reflect.TypeOf(0), Index: 2},
+	), err, "JoinErrors option should return all the failures of sharded AddRange")
+	assert.Equal(test, 1, dictionary.Size(), "Failed AddRange calls should leave the sharded dictionary untouched")
+
+	empty := NewEmptyShardedDictionary(4)
+
+	assert.Nil(test, empty.AddRange([]KeyValueElement{{1, 1}, {2, 2}}), "Unexpected error adding a sharded range")
+	assert.Equal(test, ErrInvalidKeyValueElementType, empty.Add("3", 3), "The first range should define the types of the sharded dictionary")
+}
+
+func TestShardedElementMethods(test *testing.T) {
+	dictionary, err := NewShardedDictionary(4, []KeyValueElement{{"1Key", "1Value"}, {"2Key", "2Value"}})
+
+	assert.Nil(test, err, "Unexpected error instancing a sharded dictionary")
+
+	value, err := dictionary.Element("2Key")
+
+	assert.Nil(test, err, "Unexpected error retrieving a sharded element")
+	assert.Equal(test, "2Value", *value, "Wrong returned sharded element")
+	assert.Nil(test, dictionary.Set("2Key", "newValue"), "Unexpected error setting a sharded element")
+	assert.Equal(test, ErrElementNotFound, dictionary.Set("3Key", "3Value"), "Not found keys should return an error on sharded Set method")
+	assert.True(test, dictionary.Contains("1Key"), "Contains return a false negative on sharded dictionary")
+	assert.Nil(test, dictionary.Delete("1Key"), "Unexpected error deleting a sharded element")
+	assert.False(test, dictionary.Contains("1Key"), "Deleted keys should not be contained anymore")
+	assert.Equal(test, KeyValueMap{"2Key": "newValue"}, *dictionary.Elements(), "Wrong remained sharded elements")
+}
+
+func TestShardedSnapshotMethods(test *testing.T) {
+	dictionary := NewEmptyShardedDictionary(4)
+
+	for x := 0; x < 50; x++ {
+		dictionary.Add(x, strconv.Itoa(x))
+	}
+
+	assert.Len(test, dictionary.Keys(), 50, "Wrong number of keys on sharded Keys method")
+	assert.Len(test, dictionary.Values(), 50, "Wrong number of values on sharded Values method")
+	assert.Contains(test, dictionary.Keys(), 25, "Wrong keys on sharded Keys method")
+	assert.Contains(test, dictionary.Values(), "25", "Wrong values on sharded Values method")
+}
+
+func TestShardedRangeMethod(test *testing.T) {
+	dictionary := NewEmptyShardedDictionary(4)
+
+	for x := 0; x < 100; x++ {
+		dictionary.Add(x, x)
+	}
+
+	var sum int64
+
+	dictionary.Range(func(key KeyElement, value ValueElement) bool {
+		atomic.AddInt64(&sum, int64(value.(int)))
+
+		return true
+	})
+
+	assert.EqualValues(test, 4950, sum, "Range should visit all the elements")
+
+	var visited int64
+
+	dictionary.Range(func(KeyElement, ValueElement) bool {
+		atomic.AddInt64(&visited, 1)
+
+		return false
+	})
+
+	assert.True(test, visited <= 4, "Range should stop when the function returns false")
+}
+
+func TestShardedConcurrentAccess(test *testing.T) {
+	dictionary := NewEmptyShardedDictionary(0)
+
+	var group sync.WaitGroup
+
+	for worker := 0; worker < goroutines; worker++ {
+		group.Add(1)
+
+		go func() {
+			defer group.Done()
+
+			for key := 0; key < 200; key++ {
+				dictionary.Add(key, key)
+				dictionary.Element(key)
+				dictionary.Keys()
+			}
+		}()
+	}
+
+	group.Wait()
+
+	assert.Equal(test, 200, dictionary.Size(), "Concurrent additions should keep the keys unique")
+}
+
+const benchmarkKeys = 1024
+
+func BenchmarkShardedDictionary(benchmark *testing.B) {
+	dictionary := NewEmptyShardedDictionary(0)
+
+	benchmark.RunParallel(func(pb *testing.PB) {
+		for x := 0; pb.Next(); x++ {
+			key := x % benchmarkKeys
+
+			if x%4 == 0 {
+				dictionary.Add(key, x)
+			} else {
+				dictionary.Element(key)
+			}
+		}
+	})
+}
+
+func BenchmarkSyncDictionary(benchmark *testing.B) {
+	dictionary := NewEmptySyncDictionary()
+
+	benchmark.RunParallel(func(pb *testing.PB) {
+		for x := 0; pb.Next(); x++ {
+			key := x % benchmarkKeys
+
+			if x%4 == 0 {
+				dictionary.Add(key, x)
+			} else {
+				dictionary.Element(key)
+			}
+		}
+	})
+}
+
+func BenchmarkSyncMap(benchmark *testing.B) {
+	var dictionary sync.Map
+
+	benchmark.RunParallel(func(pb *testing.PB) {
+		for x := 0; pb.Next(); x++ {
+			key := x % benchmarkKeys
+
+			if x%4 == 0 {
+				dictionary.LoadOrStore(key, x)
+			} else {
+				dictionary.Load(key)
+			}
+		}
+	})
+}
+
+func TestShardedMutatedPointerKeys(test *testing.T) {
+	type key struct {
+		Name   string
+		Parent *[]string
+	}
+
+	parent := []string{"a"}
+	pointer := &parent
+	pointers := NewEmptyShardedDictionary(16)
+	structs := NewEmptyShardedDictionary(16)
+
+	pointers.Add(pointer, 1)
+	structs.Add(key{"b", pointer}, 2)
+
+	parent[0] = "mutated"
+	parent = append(parent, "grown")
+
+	assert.True(test, pointers.Contains(pointer), "Mutating the value pointed by a key shouldn't lose it")
+	assert.True(test, structs.Contains(key{"b", pointer}), "Mutating the value pointed by a key field shouldn't lose it")
+	assert.Nil(test, pointers.Delete(pointer), "Unexpected error deleting a mutated pointer key")
+	assert.True(test, pointers.IsEmpty(), "Mutated pointer keys should be deleted")
+}
@@ -0,0 +1,677 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// Package datatypes/dictionary provides an easy dictionary (key => value) homogeneous
+// struct management, making the iteration of a unique-key lists more powerful,
+// simple and clean, accepting primitives types and complex user structs as well.
+
+// This part of package contains the key-sorted dictionary
+
+package dictionary
+
+import (
+	"reflect"
+
+	"github.com/jaimelopez/datatypes/generic"
+)
+
+// SortedDictionary represents a dictionary (key => value) struct which keeps its
+// elements sorted by key, using a comparator function or the natural order of the keys
+// It's backed by a left-leaning red-black tree, so all the operations over
+// a single key have a logarithmic cost
+// The zero value is an empty dictionary sorted by the natural order of its keys
+type SortedDictionary struct {
+	keyDefinition   reflect.Type
+	valueDefinition reflect.Type
+	comparator      generic.Comparator
+	root            *treeNode
+}
+
+type treeNode struct {
+	key   KeyElement
+	value ValueElement
+	left  *treeNode
+	right *treeNode
+	red   bool
+	size  int
+}
+
+// Add a key-value element to the dictionary
+// It follows the same rules than the Add method of Dictionary
+// If the dictionary uses the natural order, the keys must be integers, floats or strings
+func (dic *SortedDictionary) Add(key KeyElement, value ValueElement) error {
+	if dic.IsEmpty() {
+		if dic.comparator == nil && !generic.IsOrdered(reflect.TypeOf(key)) {
+			return ErrNonOrderedKey
+		}
+
+		dic.keyDefinition = reflect.TypeOf(key)
+		dic.valueDefinition = reflect.TypeOf(value)
+	} else if !dic.isHomogeneousWith(key, value) {
+		return ErrInvalidKeyValueElementType
+	}
+
+	if dic.Contains(key) {
+		return ErrDuplicatedKey
+	}
+
+	dic.root = dic.put(dic.root, key, value)
+	dic.root.red = false
+
+	return nil
+}
+
+// AddKeyValueElement adds an composed element KeyValueElement to the dictionary
+func (dic *SortedDictionary) AddKeyValueElement(element KeyValueElement) error {
+	return dic.Add(element.Key, element.Value)
+}
+
+// AddRange inserts a range (slice) of KeyValueElement inside the dictionary
+// It follows the same rules than the AddRange method of Dictionary,
+// considering duplicated the keys which are equal for the comparator
+func (dic *SortedDictionary) AddRange(elements []KeyValueElement, options ...RangeOption) error {
+	if len(elements) == 0 {
+		return nil
+	}
+
+	keyDefinition, valueDefinition := dic.keyDefinition, dic.valueDefinition
+
+	if dic.IsEmpty() {
+		keyDefinition, valueDefinition = reflect.TypeOf(elements[0].Key), reflect.TypeOf(elements[0].Value)
+
+		if dic.comparator == nil && !generic.IsOrdered(keyDefinition) {
+			return ErrNonOrderedKey
+		}
+	}
+
+	staged := &SortedDictionary{comparator: dic.comparator}
+
+	err := checkElements(elements, keyDefinition, valueDefinition, func(position int, element KeyValueElement) error {
+		if dic.Contains(element.Key) || staged.AddKeyValueElement(element) != nil {
+			return &DuplicateError{Key: element.Key, Index: position}
+		}
+
+		return nil
+	}, options)
+
+	if err != nil {
+		return err
+	}
+
+	for _, element := range elements {
+		dic.AddKeyValueElement(element)
+	}
+
+	return nil
+}
+
+// Element returns the specified key element in the dictionary
+func (dic *SortedDictionary) Element(key KeyElement) (*ValueElement, error) {
+	node := dic.find(key)
+
+	if node == nil {
+		return nil, ErrElementNotFound
+	}
+
+	value := node.value
+
+	return &value, nil
+}
+
+// Elements returns a map with the stored elements as the Elements method of Dictionary does
+// Use Entries to retrieve them sorted by key
+func (dic *SortedDictionary) Elements() *KeyValueMap {
+	elements := make(KeyValueMap, dic.Size())
+
+	walk(dic.root, func(node *treeNode) {
+		elements[node.key] = node.value
+	})
+
+	return &elements
+}
+
+// Entries returns the stored elements sorted by key
+func (dic *SortedDictionary) Entries() []KeyValueElement {
+	elements := []KeyValueElement{}
+
+	walk(dic.root, func(node *treeNode) {
+		elements = append(elements, KeyValueElement{node.key, node.value})
+	})
+
+	return elements
+}
+
+// Keys returns all the keys in the dicionary sorted
+func (dic *SortedDictionary) Keys() []KeyElement {
+	keys := []KeyElement{}
+
+	walk(dic.root, func(node *treeNode) {
+		keys = append(keys, node.key)
+	})
+
+	return keys
+}
+
+// Values returns all the values in the dicionary sorted by their keys
+func (dic *SortedDictionary) Values() []ValueElement {
+	values := []ValueElement{}
+
+	walk(dic.root, func(node *treeNode) {
+		values = append(values, node.value)
+	})
+
+	return values
+}
+
+// Extract the element with the lowest key and return it
+// If the dictionary is empty it returns nil
+// Keep in mind that this method will modify the dictionary elements subtracting that element
+func (dic *SortedDictionary) Extract() *KeyValueElement {
+	element := dic.Min()
+
+	if element != nil {
+		dic.Delete(element.Key)
+	}
+
+	return element
+}
+
+// ExtractKey extracts the specified key element and return it
+// Keep in mind that this method will modify the dictionary elements subtracting that element
+func (dic *SortedDictionary) ExtractKey(key KeyElement) (*KeyValueElement, error) {
+	node := dic.find(key)
+
+	if node == nil {
+		return nil, ErrElementNotFound
+	}
+
+	element := &KeyValueElement{node.key, node.value}
+	dic.Delete(key)
+
+	return element, nil
+}
+
+// Set a new value for a specified index element
+func (dic *SortedDictionary) Set(key KeyElement, value ValueElement) error {
+	if !dic.isHomogeneousWith(key, value) {
+		return ErrInvalidKeyValueElementType
+	}
+
+	node := dic.find(key)
+
+	if node == nil {
+		return ErrElementNotFound
+	}
+
+	node.value = value
+
+	return nil
+}
+
+// Delete an specified already stored element
+// If it's not found the method will return an error
+func (dic *SortedDictionary) Delete(key KeyElement) error {
+	if !dic.Contains(key) {
+		return ErrElementNotFound
+	}
+
+	if !isRed(dic.root.left) && !isRed(dic.root.right) {
+		dic.root.red = true
+	}
+
+	dic.root = dic.delete(dic.root, key)
+
+	if dic.root != nil {
+		dic.root.red = false
+	}
+
+	return nil
+}
+
+// Contains checks if the specified key element is already existing in the dictionary
+func (dic *SortedDictionary) Contains(key KeyElement) bool {
+	return dic.find(key) != nil
+}
+
+// ContainsValue checks if the specified value element exists in the dictionary
+func (dic *SortedDictionary) ContainsValue(element ValueElement) bool {
+	for _, value := range dic.Values() {
+		if reflect.DeepEqual(value, element) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Filter returns a map with the elements which satisfy the specified function
+// as the Filter method of Dictionary does
+// If the functions return true the element will be filtered
+func (dic *SortedDictionary) Filter(f func(KeyValueElement) bool) *KeyValueMap {
+	results := make(KeyValueMap)
+
+	walk(dic.root, func(node *treeNode) {
+		if f(KeyValueElement{node.key, node.value}) {
+			results[node.key] = node.value
+		}
+	})
+
+	return &results
+}
+
+// Min returns the element with the lowest key or nil if the dictionary is empty
+func (dic *SortedDictionary) Min() *KeyValueElement {
+	if dic.IsEmpty() {
+		return nil
+	}
+
+	node := dic.root
+
+	for node.left != nil {
+		node = node.left
+	}
+
+	return &KeyValueElement{node.key, node.value}
+}
+
+// Max returns the element with the greatest key or nil if the dictionary is empty
+func (dic *SortedDictionary) Max() *KeyValueElement {
+	if dic.IsEmpty() {
+		return nil
+	}
+
+	node := dic.root
+
+	for node.right != nil {
+		node = node.right
+	}
+
+	return &KeyValueElement{node.key, node.value}
+}
+
+// Floor returns the element with the greatest key lower than or equal to the specified one
+// If there is no such element it returns nil
+func (dic *SortedDictionary) Floor(key KeyElement) *KeyValueElement {
+	return dic.closest(key, func(order int) bool { return order >= 0 }, true)
+}
+
+// Ceiling returns the element with the lowest key greater than or equal to the specified one
+// If there is no such element it returns nil
+func (dic *SortedDictionary) Ceiling(key KeyElement) *KeyValueElement {
+	return dic.closest(key, func(order int) bool { return order <= 0 }, false)
+}
+
+// Lower returns the element with the greatest key strictly lower than the specified one
+// If there is no such element it returns nil
+func (dic *SortedDictionary) Lower(key KeyElement) *KeyValueElement {
+	return dic.closest(key, func(order int) bool { return order > 0 }, true)
+}
+
+// Higher returns the element with the lowest key strictly greater than the specified one
+// If there is no such element it returns nil
+func (dic *SortedDictionary) Higher(key KeyElement) *KeyValueElement {
+	return dic.closest(key, func(order int) bool { return order < 0 }, false)
+}
+
+// Range returns the elements whose keys are between the specified ones, both included,
+// sorted by key
+func (dic *SortedDictionary) Range(from KeyElement, to KeyElement) []KeyValueElement {
+	results := []KeyValueElement{}
+
+	if !dic.accepts(from) || !dic.accepts(to) {
+		return results
+	}
+
+	dic.collect(dic.root, from, to, &results)
+
+	return results
+}
+
+// RankOf returns the number of keys strictly lower than the specified one,
+// which is the position the key has or would have in the sorted dictionary
+func (dic *SortedDictionary) RankOf(key KeyElement) int {
+	if !dic.accepts(key) {
+		return 0
+	}
+
+	rank := 0
+	node := dic.root
+
+	for node != nil {
+		order := dic.compare(key, node.key)
+
+		if order < 0 {
+			node = node.left
+		} else if order > 0 {
+			rank += 1 + size(node.left)
+			node = node.right
+		} else {
+			return rank + size(node.left)
+		}
+	}
+
+	return rank
+}
+
+// Select returns the element in the specified position following the key order
+// If the position is out of range it returns nil
+func (dic *SortedDictionary) Select(position int) *KeyValueElement {
+	if position < 0 || position >= dic.Size() {
+		return nil
+	}
+
+	node := dic.root
+
+	for {
+		lowers := size(node.left)
+
+		if position < lowers {
+			node = node.left
+		} else if position > lowers {
+			position -= lowers + 1
+			node = node.right
+		} else {
+			return &KeyValueElement{node.key, node.value}
+		}
+	}
+}
+
+// Size returns the number of elements inside the dicionary
+func (dic *SortedDictionary) Size() int {
+	return size(dic.root)
+}
+
+// IsEmpty checks if the dictionary is empty or not
+func (dic *SortedDictionary) IsEmpty() bool {
+	return dic.Size() == 0
+}
+
+func (dic *SortedDictionary) isHomogeneousWith(key KeyElement, value ValueElement) bool {
+	return dic.keyDefinition == reflect.TypeOf(key) &&
+		dic.valueDefinition == reflect.TypeOf(value)
+}
+
+// compare sorts the keys with the comparator or by their natural order if there is none
+func (dic *SortedDictionary) compare(first KeyElement, second KeyElement) int {
+	if dic.comparator == nil {
+		return generic.Compare(first, second)
+	}
+
+	return dic.comparator(first, second)
+}
+
+// accepts checks if the key can be compared with the stored ones
+func (dic *SortedDictionary) accepts(key KeyElement) bool {
+	return !dic.IsEmpty() && dic.keyDefinition == reflect.TypeOf(key)
+}
+
+func (dic *SortedDictionary) find(key KeyElement) *treeNode {
+	if !dic.accepts(key) {
+		return nil
+	}
+
+	node := dic.root
+
+	for node != nil {
+		order := dic.compare(key, node.key)
+
+		if order < 0 {
+			node = node.left
+		} else if order > 0 {
+			node = node.right
+		} else {
+			return node
+		}
+	}
+
+	return nil
+}
+
+// closest walks the tree looking for the nearest key to the specified one which satisfies
+// the candidate function, going to the right side of the candidates when lower is true
+func (dic *SortedDictionary) closest(key KeyElement, candidate func(int) bool, lower bool) *KeyValueElement {
+	if !dic.accepts(key) {
+		return nil
+	}
+
+	var found *treeNode
+
+	node := dic.root
+
+	for node != nil {
+		if candidate(dic.compare(key, node.key)) {
+			found = node
+
+			if lower {
+				node = node.right
+			} else {
+				node = node.left
+			}
+		} else if lower {
+			node = node.left
+		} else {
+			node = node.right
+		}
+	}
+
+	if found == nil {
+		return nil
+	}
+
+	return &KeyValueElement{found.key, found.value}
+}
+
+func (dic *SortedDictionary) collect(node *treeNode, from KeyElement, to KeyElement, results *[]KeyValueElement) {
+	if node == nil {
+		return
+	}
+
+	fromOrder := dic.compare(from, node.key)
+	toOrder := dic.compare(to, node.key)
+
+	if fromOrder < 0 {
+		dic.collect(node.left, from, to, results)
+	}
+
+	if fromOrder <= 0 && toOrder >= 0 {
+		*results = append(*results, KeyValueElement{node.key, node.value})
+	}
+
+	if toOrder > 0 {
+		dic.collect(node.right, from, to, results)
+	}
+}
+
+func (dic *SortedDictionary) put(node *treeNode, key KeyElement, value ValueElement) *treeNode {
+	if node == nil {
+		return &treeNode{key: key, value: value, red: true, size: 1}
+	}
+
+	order := dic.compare(key, node.key)
+
+	if order < 0 {
+		node.left = dic.put(node.left, key, value)
+	} else if order > 0 {
+		node.right = dic.put(node.right, key, value)
+	} else {
+		node.value = value
+	}
+
+	return balance(node)
+}
+
+func (dic *SortedDictionary) delete(node *treeNode, key KeyElement) *treeNode {
+	if dic.compare(key, node.key) < 0 {
+		if !isRed(node.left) && !isRed(node.left.left) {
+			node = moveRedLeft(node)
+		}
+
+		node.left = dic.delete(node.left, key)
+
+		return balance(node)
+	}
+
+	if isRed(node.left) {
+		node = rotateRight(node)
+	}
+
+	if dic.compare(key, node.key) == 0 && node.right == nil {
+		return nil
+	}
+
+	if !isRed(node.right) && !isRed(node.right.left) {
+		node = moveRedRight(node)
+	}
+
+	if dic.compare(key, node.key) == 0 {
+		successor := node.right
+
+		for successor.left != nil {
+			successor = successor.left
+		}
+
+		node.key = successor.key
+		node.value = successor.value
+		node.right = deleteMin(node.right)
+	} else {
+		node.right = dic.delete(node.right, key)
+	}
+
+	return balance(node)
+}
+
+func walk(node *treeNode, f func(*treeNode)) {
+	if node == nil {
+		return
+	}
+
+	walk(node.left, f)
+	f(node)
+	walk(node.right, f)
+}
+
+func isRed(node *treeNode) bool {
+	return node != nil && node.red
+}
+
+func size(node *treeNode) int {
+	if node == nil {
+		return 0
+	}
+
+	return node.size
+}
+
+func rotateLeft(node *treeNode) *treeNode {
+	child := node.right
+	node.right = child.left
+	child.left = node
+	child.red = node.red
+	node.red = true
+	child.size = node.size
+	node.size = 1 + size(node.left) + size(node.right)
+
+	return child
+}
+
+func rotateRight(node *treeNode) *treeNode {
+	child := node.left
+	node.left = child.right
+	child.right = node
+	child.red = node.red
+	node.red = true
+	child.size = node.size
+	node.size = 1 + size(node.left) + size(node.right)
+
+	return child
+}
+
+func flipColors(node *treeNode) {
+	node.red = !node.red
+	node.left.red = !node.left.red
+	node.right.red = !node.right.red
+}
+
+func moveRedLeft(node *treeNode) *treeNode {
+	flipColors(node)
+
+	if isRed(node.right.left) {
+		node.right = rotateRight(node.right)
+		node = rotateLeft(node)
+		flipColors(node)
+	}
+
+	return node
+}
+
+func moveRedRight(node *treeNode) *treeNode {
+	flipColors(node)
+
+	if isRed(node.left.left) {
+		node = rotateRight(node)
+		flipColors(node)
+	}
+
+	return node
+}
+
+func deleteMin(node *treeNode) *treeNode {
+	if node.left == nil {
+		return nil
+	}
+
+	if !isRed(node.left) && !isRed(node.left.left) {
+		node = moveRedLeft(node)
+	}
+
+	node.left = deleteMin(node.left)
+
+	return balance(node)
+}
+
+func balance(node *treeNode) *treeNode {
+	if isRed(node.right) && !isRed(node.left) {
+		node = rotateLeft(node)
+	}
+
+	if isRed(node.left) && isRed(node.left.left) {
+		node = rotateRight(node)
+	}
+
+	if isRed(node.left) && isRed(node.right) {
+		flipColors(node)
+	}
+
+	node.size = 1 + size(node.left) + size(node.right)
+
+	return node
+}
+
+// NewEmptySortedDictionary instances a new empty dictionary sorted by the natural order of its keys
+func NewEmptySortedDictionary() *SortedDictionary {
+	return &SortedDictionary{}
+}
+
+// NewEmptySortedDictionaryFunc instances a new empty dictionary sorted by the specified comparator
+func NewEmptySortedDictionaryFunc(comparator generic.Comparator) *SortedDictionary {
+	return &SortedDictionary{comparator: comparator}
+}
+
+// NewSortedDictionary allows to instance a new SortedDictionary with a group of key-value elements
+// sorted by the natural order of their keys
+func NewSortedDictionary(elements []KeyValueElement) (*SortedDictionary, error) {
+	dictionary := NewEmptySortedDictionary()
+	err := dictionary.AddRange(elements)
+
+	return dictionary, err
+}
+
+// NewSortedDictionaryFunc allows to instance a new SortedDictionary with a group of key-value elements
+// sorted by the specified comparator
+func NewSortedDictionaryFunc(comparator generic.Comparator, elements []KeyValueElement) (*SortedDictionary, error) {
+	dictionary := NewEmptySortedDictionaryFunc(comparator)
+	err := dictionary.AddRange(elements)
+
+	return dictionary, err
+}
@@ -0,0 +1,188 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// Package datatypes/dictionary provides an easy dictionary (key => value) homogeneous
+// struct management, making the iteration of a unique-key lists more powerful,
+// simple and clean, accepting primitives types and complex user structs as well.
+
+// This part of package contains the tests for the key-sorted dictionary
+
+package dictionary
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func sortedDictionary() *SortedDictionary {
+	dictionary, _ := NewSortedDictionary([]KeyValueElement{
+		{30, "30Value"},
+		{10, "10Value"},
+		{50, "50Value"},
+		{20, "20Value"},
+		{40, "40Value"},
+	})
+
+	return dictionary
+}
+
+func TestSortedAddMethod(test *testing.T) {
+	dictionary := NewEmptySortedDictionary()
+
+	assert.Nil(test, dictionary.Add("key", "value"), "Unexpected error adding a sorted element")
+	assert.Equal(test, ErrDuplicatedKey, dictionary.Add("key", "value"), "Duplicated keys should return an error on sorted Add method")
+	assert.Equal(test, ErrInvalidKeyValueElementType, dictionary.Add(1, 2), "Non-homogeneous elements should return an error on sorted Add method")
+	assert.Equal(test, ErrNonOrderedKey, NewEmptySortedDictionary().Add(struct{}{}, 1), "Non-ordered keys should return an error without comparator")
+}
+
+func TestSortedAddRangeMethod(test *testing.T) {
+	dictionary, _ := NewSortedDictionary([]KeyValueElement{{"bKey", "bValue"}})
+
+	assert.Equal(test, &DuplicateError{Key: "bKey", Index: 1}, dictionary.AddRange([]KeyValueElement{{"aKey", "aValue"}, {"bKey", "bValue"}}), "Duplicated keys in range should return an error")
+	assert.Equal(test, 1, dictionary.Size(), "Failed AddRange calls should leave the sorted dictionary untouched")
+
+	err := dictionary.AddRange([]KeyValueElement{{1, "aValue"}, {"aKey", "aValue"}, {"aKey", "aValue"}}, JoinErrors())
+
+	assert.Equal(test, errors.Join(
+		&TypeMismatchError{Expected: reflect.TypeOf(""), Actual: reflect.TypeOf(0), IsKey: true, Index: 0},
+		&DuplicateError{Key: "aKey", Index: 2},
+	), err, "JoinErrors option should return all the failures of sorted AddRange")
+	assert.Equal(test, 1, dictionary.Size(), "Failed AddRange calls should leave the sorted dictionary untouched")
+
+	insensitive := NewEmptySortedDictionaryFunc(func(first interface{}, second interface{}) int {
+		return strings.Compare(strings.ToLower(first.(string)), strings.ToLower(second.(string)))
+	})
+
+	assert.Equal(test, &DuplicateError{Key: "A", Index: 1}, insensitive.AddRange([]KeyValueElement{{"a", 1}, {"A", 2}}), "Keys equal for the comparator in range should be duplicated")
+	assert.True(test, insensitive.IsEmpty(), "Failed AddRange calls should leave the sorted dictionary untouched")
+	assert.Equal(test, ErrNonOrderedKey, NewEmptySortedDictionary().AddRange([]KeyValueElement{{struct{}{}, 1}}), "Non-ordered keys in range should return an error without comparator")
+}
+
+func TestSortedIterationMethods(test *testing.T) {
+	dictionary := sortedDictionary()
+
+	assert.Equal(test, []KeyElement{10, 20, 30, 40, 50}, dictionary.Keys(), "Keys should be returned sorted")
+	assert.Equal(test, []ValueElement{"10Value", "20Value", "30Value", "40Value", "50Value"}, dictionary.Values(), "Values should be returned sorted by key")
+	assert.Equal(test, KeyValueElement{10, "10Value"}, dictionary.Entries()[0], "Entries should be returned sorted by key")
+	assert.Equal(test, &KeyValueMap{10: "10Value", 20: "20Value", 30: "30Value", 40: "40Value", 50: "50Value"}, dictionary.Elements(), "Wrong elements of the sorted dictionary")
+
+	matches := dictionary.Filter(func(elem KeyValueElement) bool { return elem.Key.(int) > 30 })
+
+	assert.Equal(test, &KeyValueMap{40: "40Value", 50: "50Value"}, matches, "Wrong filtered elements of the sorted dictionary")
+}
+
+func TestSortedZeroValue(test *testing.T) {
+	var dictionary SortedDictionary
+
+	assert.Nil(test, dictionary.Add(20, "20Value"), "Unexpected error adding to a zero value sorted dictionary")
+	assert.Nil(test, dictionary.Add(10, "10Value"), "Unexpected error adding to a zero value sorted dictionary")
+	assert.Equal(test, []KeyElement{10, 20}, dictionary.Keys(), "Zero value sorted dictionaries should use the natural order")
+	assert.Equal(test, ErrNonOrderedKey, (&SortedDictionary{}).Add(struct{}{}, 1), "Non-ordered keys should return an error on zero value sorted dictionaries")
+}
+
+func TestSortedElementMethods(test *testing.T) {
+	dictionary := sortedDictionary()
+
+	value, err := dictionary.Element(20)
+
+	assert.Nil(test, err, "Unexpected error retrieving a sorted element")
+	assert.Equal(test, "20Value", *value, "Wrong value retrieving a sorted element")
+	assert.Nil(test, dictionary.Set(20, "newValue"), "Unexpected error setting a sorted element")
+	assert.Equal(test, ErrElementNotFound, dictionary.Set(25, "newValue"), "Not found keys should return an error on sorted Set method")
+	assert.False(test, dictionary.Contains("20"), "Contains should return false with non-homogeneous keys")
+	assert.True(test, dictionary.ContainsValue("newValue"), "ContainsValue return a false negative on sorted dictionary")
+}
+
+func TestSortedExtractMethods(test *testing.T) {
+	dictionary := sortedDictionary()
+
+	assert.Equal(test, KeyValueElement{10, "10Value"}, *dictionary.Extract(), "Extract should return the lowest key element")
+
+	extracted, err := dictionary.ExtractKey(40)
+
+	assert.Nil(test, err, "Unexpected error on sorted ExtractKey method")
+	assert.Equal(test, KeyValueElement{40, "40Value"}, *extracted, "Wrong extracted element on sorted ExtractKey method")
+	assert.Equal(test, []KeyElement{20, 30, 50}, dictionary.Keys(), "Wrong remained keys after extractions")
+}
+
+func TestSortedNavigationMethods(test *testing.T) {
+	dictionary := sortedDictionary()
+
+	assert.Equal(test, 10, dictionary.Min().Key, "Wrong element on Min method")
+	assert.Equal(test, 50, dictionary.Max().Key, "Wrong element on Max method")
+	assert.Equal(test, 20, dictionary.Floor(25).Key, "Wrong element on Floor method")
+	assert.Equal(test, 20, dictionary.Floor(20).Key, "Floor method should include the equal key")
+	assert.Equal(test, 30, dictionary.Ceiling(25).Key, "Wrong element on Ceiling method")
+	assert.Equal(test, 10, dictionary.Lower(20).Key, "Lower method should exclude the equal key")
+	assert.Equal(test, 30, dictionary.Higher(20).Key, "Higher method should exclude the equal key")
+	assert.Nil(test, dictionary.Floor(5), "Floor should return nil when there is no lower key")
+	assert.Nil(test, dictionary.Higher(50), "Higher should return nil when there is no greater key")
+	assert.Nil(test, NewEmptySortedDictionary().Min(), "Min should return nil on empty dictionaries")
+}
+
+func TestSortedRangeMethod(test *testing.T) {
+	dictionary := sortedDictionary()
+
+	assert.Equal(test, []KeyValueElement{{20, "20Value"}, {30, "30Value"}, {40, "40Value"}}, dictionary.Range(15, 40), "Wrong elements on Range method")
+	assert.Empty(test, dictionary.Range(31, 39), "Range without keys should return no elements")
+}
+
+func TestSortedRankMethods(test *testing.T) {
+	dictionary := sortedDictionary()
+
+	assert.Equal(test, 0, dictionary.RankOf(10), "Wrong rank of the lowest key")
+	assert.Equal(test, 2, dictionary.RankOf(25), "Wrong rank of a non-existent key")
+	assert.Equal(test, 5, dictionary.RankOf(60), "Wrong rank of a key greater than all")
+	assert.Equal(test, 40, dictionary.Select(3).Key, "Wrong element on Select method")
+	assert.Nil(test, dictionary.Select(5), "Out of range positions should return nil on Select method")
+}
+
+func TestSortedComparator(test *testing.T) {
+	dictionary, err := NewSortedDictionaryFunc(func(first interface{}, second interface{}) int {
+		return strings.Compare(second.(string), first.(string))
+	}, []KeyValueElement{{"a", 1}, {"c", 3}, {"b", 2}})
+
+	assert.Nil(test, err, "Unexpected error instancing a sorted dictionary with comparator")
+	assert.Equal(test, []KeyElement{"c", "b", "a"}, dictionary.Keys(), "Keys should be sorted by the comparator")
+}
+
+func TestSortedRandomOperations(test *testing.T) {
+	dictionary := NewEmptySortedDictionary()
+	expected := map[int]bool{}
+	random := rand.New(rand.NewSource(1))
+
+	for x := 0; x < 2000; x++ {
+		key := random.Intn(300)
+
+		if expected[key] {
+			assert.Nil(test, dictionary.Delete(key), "Unexpected error deleting a random key")
+			delete(expected, key)
+		} else {
+			assert.Nil(test, dictionary.Add(key, x), "Unexpected error adding a random key")
+			expected[key] = true
+		}
+	}
+
+	keys := []KeyElement{}
+	sorted := []int{}
+
+	for key := range expected {
+		sorted = append(sorted, key)
+	}
+
+	sort.Ints(sorted)
+
+	for _, key := range sorted {
+		keys = append(keys, key)
+	}
+
+	assert.Equal(test, keys, dictionary.Keys(), "Keys should be kept sorted after random operations")
+	assert.Equal(test, len(keys), dictionary.Size(), "Size should be kept after random operations")
+}
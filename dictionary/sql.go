@@ -0,0 +1,55 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// Package datatypes/dictionary provides an easy dictionary (key => value) homogeneous
+// struct management, making the iteration of a unique-key lists more powerful,
+// simple and clean, accepting primitives types and complex user structs as well.
+
+// This part of package contains the database/sql integration of dictionaries
+
+package dictionary
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+)
+
+// Value implements driver.Valuer storing the dictionary as JSON
+// following the same rules than MarshalJSON
+func (dic *Dictionary) Value() (driver.Value, error) {
+	return dic.MarshalJSON()
+}
+
+// Scan implements sql.Scanner replacing the elements of the dictionary with the stored ones
+// NULL values are scanned as an empty dictionary. Malformed values return
+// ErrInvalidScanSource and values which can't be stored return the dictionary errors
+func (dic *Dictionary) Scan(src interface{}) error {
+	var data []byte
+
+	switch value := src.(type) {
+	case nil:
+		return dic.replace(nil)
+	case []byte:
+		data = value
+	case string:
+		data = []byte(value)
+	default:
+		return ErrInvalidScanSource
+	}
+
+	err := dic.UnmarshalJSON(data)
+
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+
+	switch {
+	case errors.As(err, &syntaxErr):
+		return ErrInvalidScanSource
+	case errors.As(err, &typeErr):
+		return ErrInvalidKeyValueElementType
+	}
+
+	return err
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// Package datatypes/dictionary provides an easy dictionary (key => value) homogeneous
+// struct management, making the iteration of a unique-key lists more powerful,
+// simple and clean, accepting primitives types and complex user structs as well.
+
+// This part of package contains the tests for the database/sql integration
+
+package dictionary
+
+import (
+	"testing"
+
+	"github.com/jaimelopez/datatypes/internal/sqltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDatabaseRoundTrip(test *testing.T) {
+	db := sqltest.Open()
+	defer db.Close()
+
+	attributes, _ := NewDictionary([]KeyValueElement{{Key: "color", Value: "red"}, {Key: "size", Value: "XL"}})
+
+	_, err := db.Exec("INSERT INTO attributes VALUES (?, ?)", 1, attributes)
+
+	assert.Nil(test, err, "Unexpected error storing a dictionary")
+
+	var stored string
+
+	db.QueryRow("SELECT attributes FROM attributes WHERE id = ?", 1).Scan(&stored)
+
+	assert.JSONEq(test, `{"color": "red", "size": "XL"}`, stored, "Dictionaries should be stored as JSON objects")
+
+	scanned := NewEmptyDictionary()
+
+	assert.Nil(test, db.QueryRow("SELECT attributes FROM attributes WHERE id = ?", 1).Scan(scanned), "Unexpected error scanning a dictionary")
+	assert.Equal(test, attributes.Elements(), scanned.Elements(), "Wrong round trip through the database")
+}
+
+func TestScanMethod(test *testing.T) {
+	dictionary := NewEmptyDictionary().DecodeAs(nil, 0)
+
+	assert.Nil(test, dictionary.Scan([]byte(`{"1Key": 1}`)), "Unexpected error scanning a JSON object")
+	assert.Equal(test, KeyValueMap{"1Key": 1}, *dictionary.Elements(), "Wrong elements scanning a JSON object")
+
+	assert.Equal(test, ErrInvalidKeyValueElementType, dictionary.Scan(`{"1Key": "one"}`), "Wrong typed values should return an error")
+	assert.Equal(test, ErrDuplicatedKey, dictionary.Scan(`[{"key": "2Key", "value": 1}, {"key": "2Key", "value": 2}]`), "Duplicated keys should return an error")
+	assert.Equal(test, ErrInvalidScanSource, dictionary.Scan(`{"1Key": `), "Malformed JSON should return an error")
+	assert.Equal(test, ErrInvalidScanSource, dictionary.Scan(42), "Unsupported sources should return an error")
+	assert.Equal(test, KeyValueMap{"1Key": 1}, *dictionary.Elements(), "Failed scans should leave the dictionary untouched")
+
+	assert.Nil(test, dictionary.Scan(nil), "Unexpected error scanning a NULL value")
+	assert.True(test, dictionary.IsEmpty(), "NULL values should be scanned as empty dictionaries")
+}
@@ -0,0 +1,227 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// Package datatypes/dictionary provides an easy dictionary (key => value) homogeneous
+// struct management, making the iteration of a unique-key lists more powerful,
+// simple and clean, accepting primitives types and complex user structs as well.
+
+// This part of package contains the thread-safe dictionary
+
+package dictionary
+
+import "sync"
+
+// SyncDictionary represents a dictionary safe for concurrent use by multiple goroutines
+// Read operations are allowed to run in parallel while the mutating ones are exclusive
+type SyncDictionary struct {
+	mutex      sync.RWMutex
+	dictionary *Dictionary
+}
+
+// Add a key-value element to the dictionary
+// It follows the same rules than the Add method of Dictionary
+func (dic *SyncDictionary) Add(key KeyElement, value ValueElement) error {
+	dic.mutex.Lock()
+	defer dic.mutex.Unlock()
+
+	return dic.dictionary.Add(key, value)
+}
+
+// AddKeyValueElement adds an composed element KeyValueElement to the dictionary
+func (dic *SyncDictionary) AddKeyValueElement(element KeyValueElement) error {
+	return dic.Add(element.Key, element.Value)
+}
+
+// AddRange inserts a range (slice) of KeyValueElement inside the dictionary
+func (dic *SyncDictionary) AddRange(elements []KeyValueElement, options ...RangeOption) error {
+	dic.mutex.Lock()
+	defer dic.mutex.Unlock()
+
+	return dic.dictionary.AddRange(elements, options...)
+}
+
+// AddIfAbsent adds the key-value element only if the key is not contained yet
+// in the dictionary checking and adding it atomically
+// It returns true if the element has been added
+func (dic *SyncDictionary) AddIfAbsent(key KeyElement, value ValueElement) (bool, error) {
+	dic.mutex.Lock()
+	defer dic.mutex.Unlock()
+
+	if dic.dictionary.Contains(key) {
+		return false, nil
+	}
+
+	if err := dic.dictionary.Add(key, value); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ComputeIfAbsent returns the value of the specified key and, if it doesn't exist yet,
+// computes it with the specified function and adds it atomically
+// The function must not call any other method of the dictionary
+func (dic *SyncDictionary) ComputeIfAbsent(key KeyElement, f func(KeyElement) ValueElement) (ValueElement, error) {
+	dic.mutex.Lock()
+	defer dic.mutex.Unlock()
+
+	if value, exists := dic.dictionary.elements[key]; exists {
+		return value, nil
+	}
+
+	value := f(key)
+
+	if err := dic.dictionary.Add(key, value); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+// Element returns the specified key element in the dictionary
+func (dic *SyncDictionary) Element(key KeyElement) (*ValueElement, error) {
+	dic.mutex.RLock()
+	defer dic.mutex.RUnlock()
+
+	return dic.dictionary.Element(key)
+}
+
+// Elements returns a copy of the stored elements
+func (dic *SyncDictionary) Elements() *KeyValueMap {
+	dic.mutex.RLock()
+	defer dic.mutex.RUnlock()
+
+	return dic.dictionary.clone().Elements()
+}
+
+// Keys returns all the keys in the dicionary as a list of KeyElement
+func (dic *SyncDictionary) Keys() []KeyElement {
+	dic.mutex.RLock()
+	defer dic.mutex.RUnlock()
+
+	return dic.dictionary.Keys()
+}
+
+// Values returns all the values in the dicionary as a list of ValueElement
+func (dic *SyncDictionary) Values() []ValueElement {
+	dic.mutex.RLock()
+	defer dic.mutex.RUnlock()
+
+	return dic.dictionary.Values()
+}
+
+// Extract the first element and return it
+// If the dictionary is empty it returns nil
+func (dic *SyncDictionary) Extract() *KeyValueElement {
+	dic.mutex.Lock()
+	defer dic.mutex.Unlock()
+
+	return dic.dictionary.Extract()
+}
+
+// ExtractKey extracts the specified key element and return it
+func (dic *SyncDictionary) ExtractKey(key KeyElement) (*KeyValueElement, error) {
+	dic.mutex.Lock()
+	defer dic.mutex.Unlock()
+
+	return dic.dictionary.ExtractKey(key)
+}
+
+// ExtractIf removes atomically all the elements which satisfy the specified function
+// and returns them
+// The function must not call any other method of the dictionary
+func (dic *SyncDictionary) ExtractIf(f func(KeyValueElement) bool) *KeyValueMap {
+	dic.mutex.Lock()
+	defer dic.mutex.Unlock()
+
+	extracted := dic.dictionary.Filter(f)
+
+	for key := range *extracted {
+		dic.dictionary.Delete(key)
+	}
+
+	return extracted
+}
+
+// Set a new value for a specified index element
+func (dic *SyncDictionary) Set(key KeyElement, value ValueElement) error {
+	dic.mutex.Lock()
+	defer dic.mutex.Unlock()
+
+	return dic.dictionary.Set(key, value)
+}
+
+// Delete an specified already stored element
+// If it's not found the method will return an error
+func (dic *SyncDictionary) Delete(key KeyElement) error {
+	dic.mutex.Lock()
+	defer dic.mutex.Unlock()
+
+	return dic.dictionary.Delete(key)
+}
+
+// Contains checks if the specified key element is already existing in the dictionary
+func (dic *SyncDictionary) Contains(key KeyElement) bool {
+	dic.mutex.RLock()
+	defer dic.mutex.RUnlock()
+
+	return dic.dictionary.Contains(key)
+}
+
+// ContainsValue checks if the specified value element exists in the dictionary
+func (dic *SyncDictionary) ContainsValue(value ValueElement) bool {
+	dic.mutex.RLock()
+	defer dic.mutex.RUnlock()
+
+	return dic.dictionary.ContainsValue(value)
+}
+
+// Filter returns a element map filtering the elements with a function
+// If the functions return true the element will be filtered
+// The function must not call any other method of the dictionary
+func (dic *SyncDictionary) Filter(f func(KeyValueElement) bool) *KeyValueMap {
+	dic.mutex.RLock()
+	defer dic.mutex.RUnlock()
+
+	return dic.dictionary.Filter(f)
+}
+
+// Size returns the number of elements inside the dicionary
+func (dic *SyncDictionary) Size() int {
+	dic.mutex.RLock()
+	defer dic.mutex.RUnlock()
+
+	return dic.dictionary.Size()
+}
+
+// IsEmpty checks if the dictionary is empty or not
+func (dic *SyncDictionary) IsEmpty() bool {
+	return dic.Size() == 0
+}
+
+// Dictionary returns a snapshot of the current elements as a non thread-safe dictionary
+func (dic *SyncDictionary) Dictionary() *Dictionary {
+	dic.mutex.RLock()
+	defer dic.mutex.RUnlock()
+
+	return dic.dictionary.clone()
+}
+
+// Synchronized returns a thread-safe dictionary wrapping the instanced one
+// The instanced dictionary must not be used directly anymore after calling it
+func (dic *Dictionary) Synchronized() *SyncDictionary {
+	return &SyncDictionary{dictionary: dic}
+}
+
+// NewEmptySyncDictionary instances a new empty thread-safe dictionary
+func NewEmptySyncDictionary() *SyncDictionary {
+	return NewEmptyDictionary().Synchronized()
+}
+
+// NewSyncDictionary allows to instance a new thread-safe dictionary with a group of key-value elements
+func NewSyncDictionary(elements []KeyValueElement) (*SyncDictionary, error) {
+	dictionary, err := NewDictionary(elements)
+
+	return dictionary.Synchronized(), err
+}
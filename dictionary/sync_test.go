@@ -0,0 +1,126 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// Package datatypes/dictionary provides an easy dictionary (key => value) homogeneous
+// struct management, making the iteration of a unique-key lists more powerful,
+// simple and clean, accepting primitives types and complex user structs as well.
+
+// This part of package contains the tests for the thread-safe dictionary
+
+package dictionary
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const goroutines = 8
+
+func TestSyncConcurrentAdd(test *testing.T) {
+	dictionary := NewEmptySyncDictionary()
+
+	var group sync.WaitGroup
+
+	for worker := 0; worker < goroutines; worker++ {
+		group.Add(1)
+
+		go func(worker int) {
+			defer group.Done()
+
+			for key := 0; key < 100; key++ {
+				dictionary.Add(key, worker)
+				dictionary.Contains(key)
+				dictionary.Filter(func(elem KeyValueElement) bool { return elem.Value == worker })
+			}
+		}(worker)
+	}
+
+	group.Wait()
+
+	assert.Equal(test, 100, dictionary.Size(), "Concurrent additions should keep the keys unique")
+}
+
+func TestSyncConcurrentExtract(test *testing.T) {
+	dictionary := NewEmptySyncDictionary()
+
+	for key := 0; key < 1000; key++ {
+		dictionary.Add(key, "value")
+	}
+
+	var group sync.WaitGroup
+	var extracted int64
+
+	for worker := 0; worker < goroutines; worker++ {
+		group.Add(1)
+
+		go func() {
+			defer group.Done()
+
+			for dictionary.Extract() != nil {
+				atomic.AddInt64(&extracted, 1)
+			}
+		}()
+	}
+
+	group.Wait()
+
+	assert.EqualValues(test, 1000, extracted, "Every element should be extracted exactly once")
+	assert.True(test, dictionary.IsEmpty(), "Dictionary should be empty after extracting all the elements")
+}
+
+func TestSyncComputeIfAbsentMethod(test *testing.T) {
+	dictionary := NewEmptySyncDictionary()
+
+	var group sync.WaitGroup
+	var computed int64
+
+	for worker := 0; worker < goroutines; worker++ {
+		group.Add(1)
+
+		go func() {
+			defer group.Done()
+
+			value, _ := dictionary.ComputeIfAbsent("key", func(KeyElement) ValueElement {
+				atomic.AddInt64(&computed, 1)
+
+				return "value"
+			})
+
+			assert.Equal(test, "value", value, "Wrong value returned on ComputeIfAbsent method")
+		}()
+	}
+
+	group.Wait()
+
+	assert.EqualValues(test, 1, computed, "Value should be computed only once")
+
+	_, err := dictionary.ComputeIfAbsent("otherKey", func(KeyElement) ValueElement { return 1 })
+
+	assert.Equal(test, ErrInvalidKeyValueElementType, err, "Non-homogeneous values should return an error on ComputeIfAbsent method")
+}
+
+func TestSyncAddIfAbsentMethod(test *testing.T) {
+	dictionary, _ := NewSyncDictionary([]KeyValueElement{{"key", "value"}})
+
+	added, err := dictionary.AddIfAbsent("key", "otherValue")
+
+	assert.Nil(test, err, "Unexpected error on AddIfAbsent method")
+	assert.False(test, added, "Existent keys shouldn't be added on AddIfAbsent method")
+
+	added, _ = dictionary.AddIfAbsent("otherKey", "otherValue")
+
+	assert.True(test, added, "Absent keys should be added on AddIfAbsent method")
+}
+
+func TestSyncExtractIfMethod(test *testing.T) {
+	dictionary, _ := NewSyncDictionary([]KeyValueElement{{"1Key", 1}, {"2Key", 2}, {"3Key", 3}})
+
+	extracted := *dictionary.ExtractIf(func(elem KeyValueElement) bool { return elem.Value.(int) > 1 })
+
+	assert.Equal(test, KeyValueMap{"2Key": 2, "3Key": 3}, extracted, "Wrong extracted elements on ExtractIf method")
+	assert.Equal(test, KeyValueMap{"1Key": 1}, *dictionary.Elements(), "Wrong remained elements on ExtractIf method")
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// Package datatypes/dictionary provides an easy dictionary (key => value) homogeneous
+// struct management, making the iteration of a unique-key lists more powerful,
+// simple and clean, accepting primitives types and complex user structs as well.
+
+// This part of package contains the transactions over dictionaries
+
+package dictionary
+
+// Transaction represents a group of operations over a dictionary which
+// are applied atomically, so either all of them are applied or none
+type Transaction struct {
+	dictionary *Dictionary
+	operations []func(*Dictionary) error
+	closed     bool
+}
+
+// Add stages the addition of a key-value element
+func (tx *Transaction) Add(key KeyElement, value ValueElement) error {
+	return tx.stage(func(dic *Dictionary) error {
+		return dic.Add(key, value)
+	})
+}
+
+// Set stages the replacement of the value of an already existing key
+func (tx *Transaction) Set(key KeyElement, value ValueElement) error {
+	return tx.stage(func(dic *Dictionary) error {
+		return dic.Set(key, value)
+	})
+}
+
+// Delete stages the deletion of a key
+func (tx *Transaction) Delete(key KeyElement) error {
+	return tx.stage(func(dic *Dictionary) error {
+		return dic.Delete(key)
+	})
+}
+
+// Commit applies all the staged operations in order to the dictionary
+// If any of them fails, it returns its error and the dictionary is left untouched.
+// The changes are notified all together once they're applied.
+// Either way the transaction is closed and can't be used anymore
+func (tx *Transaction) Commit() error {
+	if tx.closed {
+		return ErrTransactionClosed
+	}
+
+	tx.closed = true
+
+	var events []Event
+
+	staged := tx.dictionary.clone()
+	staged.Subscribe(func(batch []Event) {
+		events = append(events, batch...)
+	})
+
+	for _, operation := range tx.operations {
+		if err := operation(staged); err != nil {
+			return err
+		}
+	}
+
+	tx.dictionary.keyDefinition = staged.keyDefinition
+	tx.dictionary.valueDefinition = staged.valueDefinition
+	tx.dictionary.elements = staged.elements
+
+	defer tx.dictionary.batch()()
+
+	for _, event := range events {
+		tx.dictionary.notify(event)
+	}
+
+	return nil
+}
+
+// Rollback discards all the staged operations and closes the transaction
+func (tx *Transaction) Rollback() error {
+	if tx.closed {
+		return ErrTransactionClosed
+	}
+
+	tx.closed = true
+	tx.operations = nil
+
+	return nil
+}
+
+func (tx *Transaction) stage(operation func(*Dictionary) error) error {
+	if tx.closed {
+		return ErrTransactionClosed
+	}
+
+	tx.operations = append(tx.operations, operation)
+
+	return nil
+}
+
+// Begin starts a new transaction over the dictionary
+// The operations are only validated when the transaction is committed
+// against the elements the dictionary has at that moment
+func (dic *Dictionary) Begin() *Transaction {
+	return &Transaction{dictionary: dic}
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// Package datatypes/dictionary provides an easy dictionary (key => value) homogeneous
+// struct management, making the iteration of a unique-key lists more powerful,
+// simple and clean, accepting primitives types and complex user structs as well.
+
+// This part of package contains the tests for the transactions
+
+package dictionary
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransactionCommit(test *testing.T) {
+	dictionary, _ := NewDictionary([]KeyValueElement{{Key: "1Key", Value: 1}, {Key: "2Key", Value: 2}})
+	received := [][]Event{}
+
+	dictionary.Subscribe(func(events []Event) {
+		received = append(received, events)
+	})
+
+	tx := dictionary.Begin()
+	tx.Add("3Key", 3)
+	tx.Set("1Key", 10)
+	tx.Delete("2Key")
+
+	assert.Equal(test, 2, dictionary.Size(), "Staged operations shouldn't be applied before committing")
+	assert.Nil(test, tx.Commit(), "Unexpected error committing a transaction")
+	assert.Equal(test, KeyValueMap{"1Key": 10, "3Key": 3}, *dictionary.Elements(), "Wrong elements after committing a transaction")
+	assert.Len(test, received, 1, "Committed operations should be notified together")
+	assert.Len(test, received[0], 3, "Every committed operation should be notified")
+	assert.Equal(test, ErrTransactionClosed, tx.Delete("1Key"), "Committed transactions can't be used anymore")
+}
+
+func TestTransactionFailedCommit(test *testing.T) {
+	dictionary, _ := NewDictionary([]KeyValueElement{{Key: "1Key", Value: 1}})
+
+	tx := dictionary.Begin()
+	tx.Add("2Key", 2)
+	tx.Set("3Key", 3)
+
+	assert.Equal(test, ErrElementNotFound, tx.Commit(), "Failed operations should return their error on commit")
+	assert.Equal(test, KeyValueMap{"1Key": 1}, *dictionary.Elements(), "Failed commits should leave the dictionary untouched")
+}
+
+func TestTransactionRollback(test *testing.T) {
+	dictionary, _ := NewDictionary([]KeyValueElement{{Key: "1Key", Value: 1}})
+
+	tx := dictionary.Begin()
+	tx.Delete("1Key")
+
+	assert.Nil(test, tx.Rollback(), "Unexpected error rolling back a transaction")
+	assert.Equal(test, KeyValueMap{"1Key": 1}, *dictionary.Elements(), "Rolled back operations shouldn't be applied")
+	assert.Equal(test, ErrTransactionClosed, tx.Commit(), "Rolled back transactions can't be committed")
+}
+
+func TestAtomicAddRangeMethod(test *testing.T) {
+	dictionary, _ := NewDictionary([]KeyValueElement{{Key: "1Key", Value: 1}})
+
+	err := dictionary.AddRange([]KeyValueElement{{Key: "2Key", Value: 2}, {Key: "1Key", Value: 3}})
+
+	assert.Equal(test, &DuplicateError{Key: "1Key", Index: 1}, err, "Duplicated keys should return an error")
+
+	err = dictionary.AddRange([]KeyValueElement{{Key: "2Key", Value: 2}, {Key: "2Key", Value: 3}})
+
+	assert.Equal(test, &DuplicateError{Key: "2Key", Index: 1}, err, "Duplicated keys in the range should return an error")
+
+	err = dictionary.AddRange([]KeyValueElement{{Key: "2Key", Value: 2}, {Key: "3Key", Value: "3"}})
+
+	assert.ErrorIs(test, err, ErrInvalidKeyValueElementType, "Heterogeneous values should return an error")
+	assert.Equal(test, KeyValueMap{"1Key": 1}, *dictionary.Elements(), "Failed AddRange calls should leave the dictionary untouched")
+}
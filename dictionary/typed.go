@@ -0,0 +1,291 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// Package datatypes/dictionary provides an easy dictionary (key => value) homogeneous
+// struct management, making the iteration of a unique-key lists more powerful,
+// simple and clean, accepting primitives types and complex user structs as well.
+
+// This part of package contains the type-parameterized dictionary
+
+package dictionary
+
+import (
+	"reflect"
+
+	"github.com/jaimelopez/datatypes/internal/ranges"
+)
+
+// TypedKeyValueElement represents a typed Key-Value object
+type TypedKeyValueElement[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+// TypedDictionary represents a dictionary (key => value) struct whose
+// homogeneity is guaranteed at compile time by its type parameters
+// The zero value is an empty dictionary ready to use
+type TypedDictionary[K comparable, V any] struct {
+	elements map[K]V
+}
+
+// Add a key-value element to the dictionary
+// If the key is already stored in the dictionary it returns an error
+func (dic *TypedDictionary[K, V]) Add(key K, value V) error {
+	if dic.Contains(key) {
+		return ErrDuplicatedKey
+	}
+
+	dic.initialize()
+	dic.elements[key] = value
+
+	return nil
+}
+
+// AddKeyValueElement adds an composed element TypedKeyValueElement to the dictionary
+func (dic *TypedDictionary[K, V]) AddKeyValueElement(element TypedKeyValueElement[K, V]) error {
+	return dic.Add(element.Key, element.Value)
+}
+
+// AddRange inserts a range (slice) of TypedKeyValueElement inside the dictionary
+// The range is added all or nothing, so if any key is duplicated
+// the dictionary is left untouched and a DuplicateError is returned,
+// or all of them joined if the JoinErrors option is specified
+func (dic *TypedDictionary[K, V]) AddRange(elements []TypedKeyValueElement[K, V], options ...RangeOption) error {
+	staged := make(map[K]bool, len(elements))
+	failures := ranges.NewFailures(options)
+
+	for position, element := range elements {
+		if _, exists := dic.elements[element.Key]; exists || staged[element.Key] {
+			if !failures.Add(&DuplicateError{Key: element.Key, Index: position}) {
+				break
+			}
+
+			continue
+		}
+
+		staged[element.Key] = true
+	}
+
+	if err := failures.Err(); err != nil {
+		return err
+	}
+
+	dic.initialize()
+
+	for _, element := range elements {
+		dic.elements[element.Key] = element.Value
+	}
+
+	return nil
+}
+
+// Element returns the specified key element in the dictionary
+func (dic *TypedDictionary[K, V]) Element(key K) (V, error) {
+	element, exists := dic.elements[key]
+
+	if !exists {
+		return element, ErrElementNotFound
+	}
+
+	return element, nil
+}
+
+// Elements returns the stored elements as a map
+// This is the proper way to iterate over all the elements inside de dicionary
+// treating them as a normal range
+func (dic *TypedDictionary[K, V]) Elements() map[K]V {
+	return dic.elements
+}
+
+// Keys returns all the keys in the dicionary
+func (dic *TypedDictionary[K, V]) Keys() []K {
+	keys := []K{}
+
+	for current := range dic.elements {
+		keys = append(keys, current)
+	}
+
+	return keys
+}
+
+// Values returns all the values in the dicionary
+func (dic *TypedDictionary[K, V]) Values() []V {
+	values := []V{}
+
+	for _, current := range dic.elements {
+		values = append(values, current)
+	}
+
+	return values
+}
+
+// Extract the first element and return it
+// Keep in mind that this method will modify the dictionary elements subtracting that element
+func (dic *TypedDictionary[K, V]) Extract() *TypedKeyValueElement[K, V] {
+	for key, value := range dic.elements {
+		delete(dic.elements, key)
+
+		return &TypedKeyValueElement[K, V]{key, value}
+	}
+
+	return nil
+}
+
+// ExtractKey extracts the specified key element and return it
+// Keep in mind that this method will modify the dictionary elements subtracting that element
+func (dic *TypedDictionary[K, V]) ExtractKey(key K) (*TypedKeyValueElement[K, V], error) {
+	value, exists := dic.elements[key]
+
+	if !exists {
+		return nil, ErrElementNotFound
+	}
+
+	delete(dic.elements, key)
+
+	return &TypedKeyValueElement[K, V]{key, value}, nil
+}
+
+// Set a new value for a specified index element
+func (dic *TypedDictionary[K, V]) Set(key K, value V) error {
+	if !dic.Contains(key) {
+		return ErrElementNotFound
+	}
+
+	dic.elements[key] = value
+
+	return nil
+}
+
+// Delete an specified already stored element
+// If it's not found the method will return an error
+func (dic *TypedDictionary[K, V]) Delete(key K) error {
+	if !dic.Contains(key) {
+		return ErrElementNotFound
+	}
+
+	delete(dic.elements, key)
+
+	return nil
+}
+
+// Contains checks if the specified key element is already existing in the dictionary
+func (dic *TypedDictionary[K, V]) Contains(key K) bool {
+	_, exists := dic.elements[key]
+
+	return exists
+}
+
+// ContainsValue checks if the specified value element exists in the dictionary
+func (dic *TypedDictionary[K, V]) ContainsValue(element V) bool {
+	for _, value := range dic.elements {
+		if reflect.DeepEqual(value, element) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Filter returns a element map filtering the elements with a function
+// If the functions return true the element will be filtered
+func (dic *TypedDictionary[K, V]) Filter(f func(TypedKeyValueElement[K, V]) bool) map[K]V {
+	results := make(map[K]V)
+
+	for key, value := range dic.elements {
+		if !f(TypedKeyValueElement[K, V]{key, value}) {
+			continue
+		}
+
+		results[key] = value
+	}
+
+	return results
+}
+
+// Size returns the number of elements inside the dicionary
+func (dic *TypedDictionary[K, V]) Size() int {
+	return len(dic.elements)
+}
+
+// IsEmpty checks if the dictionary is empty or not
+func (dic *TypedDictionary[K, V]) IsEmpty() bool {
+	return dic.Size() == 0
+}
+
+// KeyValueMap returns the elements as an untyped KeyValueMap
+// The returned map doesn't share its storage with the typed dictionary
+func (dic *TypedDictionary[K, V]) KeyValueMap() KeyValueMap {
+	elements := make(KeyValueMap, len(dic.elements))
+
+	for key, value := range dic.elements {
+		elements[key] = value
+	}
+
+	return elements
+}
+
+// Dictionary converts the typed dictionary to an untyped one
+// The returned dictionary doesn't share its storage with the typed one and,
+// as any other untyped dictionary, it will check the homogeneity of the elements
+func (dic *TypedDictionary[K, V]) Dictionary() (*Dictionary, error) {
+	dictionary := NewEmptyDictionary()
+
+	for key, value := range dic.elements {
+		if err := dictionary.Add(key, value); err != nil {
+			return nil, err
+		}
+	}
+
+	return dictionary, nil
+}
+
+// initialize creates the map of the zero value
+func (dic *TypedDictionary[K, V]) initialize() {
+	if dic.elements == nil {
+		dic.elements = make(map[K]V)
+	}
+}
+
+// NewEmptyTypedDictionary instances a new empty typed dictionary
+func NewEmptyTypedDictionary[K comparable, V any]() *TypedDictionary[K, V] {
+	return &TypedDictionary[K, V]{elements: make(map[K]V)}
+}
+
+// NewTypedDictionary allows to instance a new TypedDictionary with a group of key-value elements
+func NewTypedDictionary[K comparable, V any](elements []TypedKeyValueElement[K, V]) (*TypedDictionary[K, V], error) {
+	dictionary := NewEmptyTypedDictionary[K, V]()
+	err := dictionary.AddRange(elements)
+
+	return dictionary, err
+}
+
+// FromKeyValueMap converts an untyped KeyValueMap to a TypedDictionary
+// If any of the stored keys or values has not the expected type it returns an error
+func FromKeyValueMap[K comparable, V any](elements KeyValueMap) (*TypedDictionary[K, V], error) {
+	dictionary := NewEmptyTypedDictionary[K, V]()
+
+	for key, value := range elements {
+		typedKey, ok := key.(K)
+
+		if !ok {
+			return nil, ErrInvalidKeyValueElementType
+		}
+
+		typedValue, ok := value.(V)
+
+		if !ok {
+			return nil, ErrInvalidKeyValueElementType
+		}
+
+		dictionary.elements[typedKey] = typedValue
+	}
+
+	return dictionary, nil
+}
+
+// FromDictionary converts an untyped dictionary to a TypedDictionary
+// If any of the stored keys or values has not the expected type it returns an error
+func FromDictionary[K comparable, V any](dictionary *Dictionary) (*TypedDictionary[K, V], error) {
+	return FromKeyValueMap[K, V](dictionary.elements)
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// Package datatypes/dictionary provides an easy dictionary (key => value) homogeneous
+// struct management, making the iteration of a unique-key lists more powerful,
+// simple and clean, accepting primitives types and complex user structs as well.
+
+// This part of package contains the tests for the type-parameterized dictionary
+
+package dictionary
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypedAddMethod(test *testing.T) {
+	dictionary := NewEmptyTypedDictionary[string, int]()
+
+	assert.Nil(test, dictionary.Add("key", 1), "Unexpected error adding a typed element")
+	assert.Exactly(test, 1, dictionary.elements["key"], "Wrong behaviour adding a typed element")
+	assert.Equal(test, ErrDuplicatedKey, dictionary.Add("key", 2), "Duplicated keys should return an error on typed Add method")
+}
+
+func TestTypedAddRangeMethod(test *testing.T) {
+	dictionary, _ := NewTypedDictionary([]TypedKeyValueElement[string, int]{{"1Key", 1}})
+
+	assert.Equal(test, &DuplicateError{Key: "1Key", Index: 1}, dictionary.AddRange([]TypedKeyValueElement[string, int]{{"2Key", 2}, {"1Key", 1}}), "Duplicated keys in range should return an error")
+	assert.Equal(test, 1, dictionary.Size(), "Failed AddRange calls should leave the typed dictionary untouched")
+
+	err := dictionary.AddRange([]TypedKeyValueElement[string, int]{{"1Key", 1}, {"2Key", 2}, {"2Key", 2}}, JoinErrors())
+
+	assert.Equal(test, errors.Join(
+		&DuplicateError{Key: "1Key", Index: 0},
+		&DuplicateError{Key: "2Key", Index: 2},
+	), err, "JoinErrors option should return all the failures of typed AddRange")
+	assert.Equal(test, 1, dictionary.Size(), "Failed AddRange calls should leave the typed dictionary untouched")
+}
+
+func TestTypedZeroValue(test *testing.T) {
+	var dictionary TypedDictionary[string, int]
+
+	assert.False(test, dictionary.Contains("key"), "Contains return a false positive in a zero value typed dictionary")
+	assert.Nil(test, dictionary.Add("key", 1), "Unexpected error adding to a zero value typed dictionary")
+	assert.Nil(test, dictionary.AddRange([]TypedKeyValueElement[string, int]{{"otherKey", 2}}), "Unexpected error adding a range to a zero value typed dictionary")
+	assert.Equal(test, map[string]int{"key": 1, "otherKey": 2}, dictionary.Elements(), "Wrong elements of a zero value typed dictionary")
+}
+
+func TestTypedElementMethod(test *testing.T) {
+	dictionary, _ := NewTypedDictionary([]TypedKeyValueElement[string, int]{{"1Key", 1}, {"2Key", 2}})
+
+	value, err := dictionary.Element("2Key")
+
+	assert.Nil(test, err, "Unexpected error retrieving a typed element")
+	assert.Exactly(test, 2, value, "Wrong returned typed element")
+
+	_, err = dictionary.Element("notFoundKey")
+
+	assert.Equal(test, ErrElementNotFound, err, "Not found keys should return an error on typed Element method")
+}
+
+func TestTypedKeysAndValuesMethods(test *testing.T) {
+	dictionary, _ := NewTypedDictionary([]TypedKeyValueElement[string, int]{{"1Key", 1}, {"2Key", 2}})
+
+	assert.ElementsMatch(test, []string{"1Key", "2Key"}, dictionary.Keys(), "Wrong keys on typed Keys method")
+	assert.ElementsMatch(test, []int{1, 2}, dictionary.Values(), "Wrong values on typed Values method")
+}
+
+func TestTypedSetMethod(test *testing.T) {
+	dictionary, _ := NewTypedDictionary([]TypedKeyValueElement[string, int]{{"key", 1}})
+
+	assert.Nil(test, dictionary.Set("key", 2), "Unexpected error setting a typed element")
+	assert.Exactly(test, 2, dictionary.elements["key"], "Set method doesn't works properly on typed dictionaries")
+	assert.Equal(test, ErrElementNotFound, dictionary.Set("notFoundKey", 3), "Not found keys should return an error on typed Set method")
+}
+
+func TestTypedExtractMethods(test *testing.T) {
+	dictionary, _ := NewTypedDictionary([]TypedKeyValueElement[string, int]{{"1Key", 1}, {"2Key", 2}})
+
+	extracted, err := dictionary.ExtractKey("2Key")
+
+	assert.Nil(test, err, "Unexpected error on typed ExtractKey method")
+	assert.Exactly(test, TypedKeyValueElement[string, int]{"2Key", 2}, *extracted, "Wrong extracted element on typed ExtractKey method")
+	assert.Exactly(test, TypedKeyValueElement[string, int]{"1Key", 1}, *dictionary.Extract(), "Wrong extracted element on typed Extract method")
+	assert.Nil(test, dictionary.Extract(), "Nil should be returned extracting from an empty typed dictionary")
+}
+
+func TestTypedFilterMethod(test *testing.T) {
+	dictionary, _ := NewTypedDictionary([]TypedKeyValueElement[string, int]{{"1Key", 1}, {"2Key", 2}})
+
+	matches := dictionary.Filter(func(elem TypedKeyValueElement[string, int]) bool {
+		return elem.Value > 1
+	})
+
+	assert.Equal(test, map[string]int{"2Key": 2}, matches, "Wrong filtered typed elements!")
+}
+
+func TestTypedDictionaryConversion(test *testing.T) {
+	typed, _ := NewTypedDictionary([]TypedKeyValueElement[string, int]{{"1Key", 1}, {"2Key", 2}})
+
+	untyped, err := typed.Dictionary()
+
+	assert.Nil(test, err, "Unexpected error converting a typed dictionary")
+	assert.Equal(test, KeyValueMap{"1Key": 1, "2Key": 2}, *untyped.Elements(), "Wrong elements converting a typed dictionary")
+	assert.Equal(test, KeyValueMap{"1Key": 1, "2Key": 2}, typed.KeyValueMap(), "Wrong elements converting a typed dictionary to KeyValueMap")
+
+	converted, err := FromDictionary[string, int](untyped)
+
+	assert.Nil(test, err, "Unexpected error converting an untyped dictionary")
+	assert.Equal(test, typed.Elements(), converted.Elements(), "Wrong elements converting an untyped dictionary")
+
+	wrongConverted, err := FromKeyValueMap[string, string](*untyped.Elements())
+
+	assert.Equal(test, ErrInvalidKeyValueElementType, err, "Converting to a wrong value type should return an error")
+	assert.Nil(test, wrongConverted, "Converting to a wrong value type should return nil")
+}
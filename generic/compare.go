@@ -0,0 +1,60 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// The datatypes/generic package includes some functionalities
+// to treat in a simple way the 'generic' objects in Go
+
+// This part of package contains the ordering of 'generic' objects
+
+package generic
+
+import (
+	"cmp"
+	"reflect"
+)
+
+// Comparator represents a function which defines the order between two elements
+// It returns a negative number when the first element is lower than the second one,
+// a positive number when it's greater and zero if both are equal
+type Comparator func(first interface{}, second interface{}) int
+
+// Compare orders two elements following their natural order
+// Both elements must be of the same ordered type (see IsOrdered),
+// otherwise it panics with ErrNonOrderedElement
+func Compare(first interface{}, second interface{}) int {
+	firstValue := reflect.ValueOf(first)
+	secondValue := reflect.ValueOf(second)
+
+	if !IsOrdered(firstValue.Type()) || firstValue.Type() != secondValue.Type() {
+		panic(ErrNonOrderedElement)
+	}
+
+	switch firstValue.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(firstValue.Int(), secondValue.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(firstValue.Uint(), secondValue.Uint())
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(firstValue.Float(), secondValue.Float())
+	}
+
+	return cmp.Compare(firstValue.String(), secondValue.String())
+}
+
+// IsOrdered checks if the values of the specified type have a natural order,
+// which is the case of integers, floats and strings
+func IsOrdered(definition reflect.Type) bool {
+	if definition == nil {
+		return false
+	}
+
+	switch definition.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.String:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// The datatypes/generic package includes some functionalities
+// to treat in a simple way the 'generic' objects in Go
+
+// This part of package contains the tests for the ordering of elements
+
+package generic
+
+import (
+	"reflect"
+	"testing"
+)
+
+type orderedString string
+
+func TestCompareMethod(test *testing.T) {
+	if Compare(1, 2) >= 0 || Compare(2, 1) <= 0 || Compare(2, 2) != 0 {
+		test.Error("Wrong natural order of integers")
+	}
+
+	if Compare(uint8(3), uint8(1)) <= 0 {
+		test.Error("Wrong natural order of unsigned integers")
+	}
+
+	if Compare(1.5, 1.25) <= 0 {
+		test.Error("Wrong natural order of floats")
+	}
+
+	if Compare(orderedString("a"), orderedString("b")) >= 0 {
+		test.Error("Wrong natural order of named strings")
+	}
+
+	defer func() {
+		if recover() != ErrNonOrderedElement {
+			test.Error("Comparing non-homogeneous elements should panic with ErrNonOrderedElement")
+		}
+	}()
+
+	Compare(1, "1")
+}
+
+func TestIsOrderedMethod(test *testing.T) {
+	if !IsOrdered(reflect.TypeOf("string")) || !IsOrdered(reflect.TypeOf(1.0)) {
+		test.Error("Strings and floats should be ordered")
+	}
+
+	if IsOrdered(reflect.TypeOf(struct{}{})) || IsOrdered(reflect.TypeOf(true)) || IsOrdered(nil) {
+		test.Error("Structs, booleans and nil types shouldn't be ordered")
+	}
+}
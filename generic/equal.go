@@ -0,0 +1,19 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// The datatypes/generic package includes some functionalities
+// to treat in a simple way the 'generic' objects in Go
+
+// This part of package contains the structural equality of 'generic' objects
+
+package generic
+
+import "reflect"
+
+// Equal checks if two elements are deeply equal as reflect.DeepEqual does,
+// so pointers are equal if they point to deeply equal values
+// It's the equality Hash is consistent with, so equal elements always have the same hash
+func Equal(first interface{}, second interface{}) bool {
+	return reflect.DeepEqual(first, second)
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// The datatypes/generic package includes some functionalities
+// to treat in a simple way the 'generic' objects in Go
+
+// This part of package contains the tests for the structural equality
+
+package generic
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEqualMethod(test *testing.T) {
+	parent := &hashedStruct{Name: "parent"}
+
+	if !Equal(hashedStruct{"a", []string{"b"}, parent}, hashedStruct{"a", []string{"b"}, parent}) {
+		test.Error("Structurally equal elements should be equal")
+	}
+
+	if !Equal(hashedStruct{Parent: parent}, hashedStruct{Parent: &hashedStruct{Name: "parent"}}) {
+		test.Error("Pointers should be compared by their pointed values")
+	}
+
+	if Hash(hashedStruct{Parent: parent}) != Hash(hashedStruct{Parent: &hashedStruct{Name: "parent"}}) {
+		test.Error("Equal pointers should have the same hash")
+	}
+
+	if !Equal(map[string][]int{"a": {1}}, map[string][]int{"a": {1}}) || Equal(map[string]int{"a": 1}, map[string]int{"b": 1}) {
+		test.Error("Maps should be compared by their entries")
+	}
+
+	if Equal([]int(nil), []int{}) || Equal(1, int64(1)) || Equal(math.NaN(), math.NaN()) {
+		test.Error("Equal should follow the reflect.DeepEqual rules")
+	}
+
+	if !Equal(nil, nil) || Equal(nil, 0) {
+		test.Error("Nil elements should only be equal to nil")
+	}
+
+	if Equal(func() {}, func() {}) {
+		test.Error("Non nil functions shouldn't be equal")
+	}
+
+	cyclic := []interface{}{nil}
+	cyclic[0] = cyclic
+
+	if !Equal(cyclic, cyclic) {
+		test.Error("Cyclic structures should be compared without recursing forever")
+	}
+}
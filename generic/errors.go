@@ -9,7 +9,29 @@
 
 package generic
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
 
 // ErrInvalidIterableElement represents an error for non-iterable elements
 var ErrInvalidIterableElement = errors.New("Non-iterable type can not be converted to slice")
+
+// ErrNonOrderedElement represents an error for elements without a natural order
+var ErrNonOrderedElement = errors.New("Non-ordered type can not be compared")
+
+// NotIterableError represents an error for a non-iterable element keeping its type
+// It's considered equal to ErrInvalidIterableElement by errors.Is
+type NotIterableError struct {
+	Type reflect.Type
+}
+
+func (err *NotIterableError) Error() string {
+	return fmt.Sprintf("Non-iterable type %v can not be converted to slice", err.Type)
+}
+
+// Is reports if the target is ErrInvalidIterableElement
+func (err *NotIterableError) Is(target error) bool {
+	return target == ErrInvalidIterableElement
+}
@@ -16,7 +16,7 @@ func ToSlice(slice interface{}) ([]interface{}, error) {
 	sliceValues := reflect.ValueOf(slice)
 
 	if sliceValues.Kind() != reflect.Slice {
-		return nil, ErrInvalidIterableElement
+		return nil, &NotIterableError{Type: reflect.TypeOf(slice)}
 	}
 
 	values := make([]interface{}, sliceValues.Len())
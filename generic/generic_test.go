@@ -10,6 +10,8 @@
 package generic
 
 import (
+	"errors"
+	"reflect"
 	"testing"
 )
 
@@ -35,6 +37,16 @@ func TestToSliceMethod(test *testing.T) {
 	} else if err == nil {
 		test.Error("Non-iterable object should return an error")
 	}
+
+	var notIterable *NotIterableError
+
+	if !errors.As(err, &notIterable) || notIterable.Type != reflect.TypeOf("") {
+		test.Error("Non-iterable object should return its type in the error")
+	}
+
+	if !errors.Is(err, ErrInvalidIterableElement) {
+		test.Error("Non-iterable object error should match ErrInvalidIterableElement")
+	}
 }
 
 func TestAreSameTypeMethod(test *testing.T) { /* @TODO */ }
@@ -0,0 +1,163 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// The datatypes/generic package includes some functionalities
+// to treat in a simple way the 'generic' objects in Go
+
+// This part of package contains the structural hashing of 'generic' objects
+
+package generic
+
+import (
+	"encoding/binary"
+	"hash"
+	"hash/fnv"
+	"io"
+	"math"
+	"reflect"
+)
+
+// maxHashDepth limits how deep the nested values are walked while hashing
+// so cyclic structures can be hashed as well
+const maxHashDepth = 16
+
+// Hash returns a structural hash of the specified element
+// The hash is consistent with reflect.DeepEqual so deeply equal elements
+// always have the same hash, although different elements could collide
+func Hash(element interface{}) uint64 {
+	hasher := fnv.New64a()
+	hashValue(hasher, reflect.ValueOf(element), 0, false)
+
+	return hasher.Sum64()
+}
+
+// HashKey returns a hash of the specified element consistent with the == operator,
+// so it can be used to hash map keys. Unlike Hash, pointers are hashed by identity,
+// so mutating the pointed value doesn't change the hash of the pointer
+func HashKey(element interface{}) uint64 {
+	hasher := fnv.New64a()
+	hashValue(hasher, reflect.ValueOf(element), 0, true)
+
+	return hasher.Sum64()
+}
+
+// IsComparable checks if comparing two values of the specified type with the == operator
+// gives the same result as comparing them with reflect.DeepEqual
+// That's the case of basic types, channels and arrays and structs composed by them,
+// but not of pointers, interfaces, slices, maps nor functions
+func IsComparable(definition reflect.Type) bool {
+	if definition == nil {
+		return false
+	}
+
+	switch definition.Kind() {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128,
+		reflect.String, reflect.Chan, reflect.UnsafePointer:
+		return true
+	case reflect.Array:
+		return IsComparable(definition.Elem())
+	case reflect.Struct:
+		for x := 0; x < definition.NumField(); x++ {
+			if !IsComparable(definition.Field(x).Type) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// hashValue writes the value in the hasher following the pointers unless identity is set
+func hashValue(hasher hash.Hash64, value reflect.Value, depth int, identity bool) {
+	if !value.IsValid() {
+		writeUint(hasher, 0)
+
+		return
+	}
+
+	writeUint(hasher, uint64(value.Kind()))
+
+	if depth > maxHashDepth {
+		return
+	}
+
+	switch value.Kind() {
+	case reflect.Bool:
+		if value.Bool() {
+			writeUint(hasher, 1)
+		} else {
+			writeUint(hasher, 0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeUint(hasher, uint64(value.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		writeUint(hasher, value.Uint())
+	case reflect.Float32, reflect.Float64:
+		writeFloat(hasher, value.Float())
+	case reflect.Complex64, reflect.Complex128:
+		writeFloat(hasher, real(value.Complex()))
+		writeFloat(hasher, imag(value.Complex()))
+	case reflect.String:
+		writeUint(hasher, uint64(value.Len()))
+		io.WriteString(hasher, value.String())
+	case reflect.Array, reflect.Slice:
+		writeUint(hasher, uint64(value.Len()))
+
+		for x := 0; x < value.Len(); x++ {
+			hashValue(hasher, value.Index(x), depth+1, identity)
+		}
+	case reflect.Struct:
+		for x := 0; x < value.NumField(); x++ {
+			hashValue(hasher, value.Field(x), depth+1, identity)
+		}
+	case reflect.Map:
+		// Map entries are hashed separately and summed up so the
+		// iteration order doesn't affect the result
+		var sum uint64
+
+		iterator := value.MapRange()
+
+		for iterator.Next() {
+			entryHasher := fnv.New64a()
+			hashValue(entryHasher, iterator.Key(), depth+1, identity)
+			hashValue(entryHasher, iterator.Value(), depth+1, identity)
+
+			sum += entryHasher.Sum64()
+		}
+
+		writeUint(hasher, uint64(value.Len()))
+		writeUint(hasher, sum)
+	case reflect.Ptr, reflect.Interface:
+		if value.Kind() == reflect.Ptr && identity {
+			writeUint(hasher, uint64(value.Pointer()))
+		} else if value.IsNil() {
+			writeUint(hasher, 0)
+		} else {
+			hashValue(hasher, value.Elem(), depth+1, identity)
+		}
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		writeUint(hasher, uint64(value.Pointer()))
+	}
+}
+
+func writeUint(hasher hash.Hash64, number uint64) {
+	var buffer [8]byte
+
+	binary.LittleEndian.PutUint64(buffer[:], number)
+	hasher.Write(buffer[:])
+}
+
+func writeFloat(hasher hash.Hash64, number float64) {
+	// Positive and negative zero are equal so both must have the same hash
+	if number == 0 {
+		number = 0
+	}
+
+	writeUint(hasher, math.Float64bits(number))
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// The datatypes/generic package includes some functionalities
+// to treat in a simple way the 'generic' objects in Go
+
+// This part of package contains the tests for the structural hashing
+
+package generic
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+type hashedStruct struct {
+	Name   string
+	Tags   []string
+	Parent *hashedStruct
+}
+
+func TestHashMethod(test *testing.T) {
+	first := hashedStruct{"first", []string{"a", "b"}, &hashedStruct{Name: "parent"}}
+	equal := hashedStruct{"first", []string{"a", "b"}, &hashedStruct{Name: "parent"}}
+	different := hashedStruct{"first", []string{"a", "c"}, &hashedStruct{Name: "parent"}}
+
+	if Hash(first) != Hash(equal) {
+		test.Error("Deeply equal elements should have the same hash")
+	}
+
+	if Hash(first) == Hash(different) {
+		test.Error("Different elements shouldn't have the same hash")
+	}
+
+	if Hash(map[string]int{"a": 1, "b": 2}) != Hash(map[string]int{"b": 2, "a": 1}) {
+		test.Error("Equal maps should have the same hash regardless of the iteration order")
+	}
+
+	if Hash(0.0) != Hash(math.Copysign(0, -1)) {
+		test.Error("Positive and negative zero should have the same hash")
+	}
+
+	cyclic := &hashedStruct{Name: "cyclic"}
+	cyclic.Parent = cyclic
+
+	if Hash(cyclic) != Hash(cyclic) {
+		test.Error("Cyclic structures should be hashed consistently")
+	}
+}
+
+func TestHashKeyMethod(test *testing.T) {
+	parent := &hashedStruct{Name: "parent"}
+	key := struct {
+		Name   string
+		Parent *hashedStruct
+	}{"key", parent}
+
+	hash := HashKey(key)
+	parent.Name = "mutated"
+
+	if HashKey(key) != hash {
+		test.Error("Mutating the pointed value shouldn't change the hash of the key")
+	}
+
+	if HashKey(parent) == HashKey(&hashedStruct{Name: "mutated"}) {
+		test.Error("Pointers should be hashed by identity as the == operator compares them")
+	}
+
+	if HashKey("key") != HashKey("key") || HashKey(interface{}(1)) != HashKey(1) {
+		test.Error("Equal keys should have the same hash")
+	}
+}
+
+func TestIsComparableMethod(test *testing.T) {
+	if !IsComparable(reflect.TypeOf(struct {
+		ID   int
+		Name string
+	}{})) {
+		test.Error("Structs of basic types should be comparable")
+	}
+
+	if IsComparable(reflect.TypeOf(&hashedStruct{})) {
+		test.Error("Pointers shouldn't be comparable since reflect.DeepEqual compares the pointed values")
+	}
+
+	if IsComparable(reflect.TypeOf([]int{})) {
+		test.Error("Slices shouldn't be comparable")
+	}
+
+	if IsComparable(nil) {
+		test.Error("Nil types shouldn't be comparable")
+	}
+}
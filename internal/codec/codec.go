@@ -0,0 +1,411 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// The datatypes/internal/codec package implements the compact binary format
+// used to serialize the homogeneous structures of datatypes
+
+// This part of package contains the core behaviour
+//
+// The format is laid out as follows, with every number as an unsigned varint
+// unless otherwise specified:
+//
+//	magic      "DTB"
+//	version    1 byte
+//	types      number of definitions followed by, for each one,
+//	           its reflect.Kind as 1 byte and the length-prefixed type name
+//	count      number of entries
+//	entries    length-prefixed payloads, cycling through the definitions
+//	checksum   CRC-32 (IEEE) of all the previous bytes as 4 big-endian bytes
+//
+// Signed integers are stored as zig-zag varints, unsigned ones as varints,
+// floats as their IEEE 754 bits in big-endian and strings as raw bytes
+
+package codec
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+	"math"
+	"reflect"
+)
+
+// Version is the current version of the binary format
+const Version = 1
+
+const (
+	magic        = "DTB"
+	checksumSize = 4
+)
+
+// basics maps every supported kind to its unnamed type
+var basics = map[reflect.Kind]reflect.Type{
+	reflect.Bool:       reflect.TypeOf(false),
+	reflect.Int:        reflect.TypeOf(int(0)),
+	reflect.Int8:       reflect.TypeOf(int8(0)),
+	reflect.Int16:      reflect.TypeOf(int16(0)),
+	reflect.Int32:      reflect.TypeOf(int32(0)),
+	reflect.Int64:      reflect.TypeOf(int64(0)),
+	reflect.Uint:       reflect.TypeOf(uint(0)),
+	reflect.Uint8:      reflect.TypeOf(uint8(0)),
+	reflect.Uint16:     reflect.TypeOf(uint16(0)),
+	reflect.Uint32:     reflect.TypeOf(uint32(0)),
+	reflect.Uint64:     reflect.TypeOf(uint64(0)),
+	reflect.Float32:    reflect.TypeOf(float32(0)),
+	reflect.Float64:    reflect.TypeOf(float64(0)),
+	reflect.Complex64:  reflect.TypeOf(complex64(0)),
+	reflect.Complex128: reflect.TypeOf(complex128(0)),
+	reflect.String:     reflect.TypeOf(""),
+}
+
+// Encoder writes homogeneous elements in the binary format
+type Encoder struct {
+	definitions []reflect.Type
+	header      []byte
+	entries     []byte
+	count       uint64
+}
+
+// Encode appends an element to the encoded data
+// The element must be the type of the definition which corresponds to its position
+func (encoder *Encoder) Encode(element interface{}) error {
+	definition := encoder.definitions[encoder.count%uint64(len(encoder.definitions))]
+	value := reflect.ValueOf(element)
+
+	if !value.IsValid() || value.Type() != definition {
+		return ErrUnsupportedType
+	}
+
+	payload := appendValue(nil, value)
+
+	encoder.entries = binary.AppendUvarint(encoder.entries, uint64(len(payload)))
+	encoder.entries = append(encoder.entries, payload...)
+	encoder.count++
+
+	return nil
+}
+
+// Bytes returns the encoded data including its checksum
+func (encoder *Encoder) Bytes() []byte {
+	data := append([]byte(nil), encoder.header...)
+	data = binary.AppendUvarint(data, encoder.count)
+	data = append(data, encoder.entries...)
+
+	return binary.BigEndian.AppendUint32(data, crc32.ChecksumIEEE(data))
+}
+
+// Seal returns a copy of the data whose trailing checksum is recomputed from the rest of bytes
+// It allows to build tampered data which passes the integrity check, as the fuzz tests do
+func Seal(data []byte) []byte {
+	if len(data) < checksumSize {
+		return data
+	}
+
+	content := append([]byte(nil), data[:len(data)-checksumSize]...)
+
+	return binary.BigEndian.AppendUint32(content, crc32.ChecksumIEEE(content))
+}
+
+// Decoder reads homogeneous elements from data in the binary format
+type Decoder struct {
+	definitions []reflect.Type
+	data        []byte
+	remaining   uint64
+	decoded     uint64
+}
+
+// Definitions returns the types which the elements will be decoded into
+// A nil definition means that the encoded structure was empty
+func (decoder *Decoder) Definitions() []reflect.Type {
+	return decoder.definitions
+}
+
+// More checks if there are elements left to decode
+func (decoder *Decoder) More() bool {
+	return decoder.remaining > 0
+}
+
+// Decode returns the next element
+func (decoder *Decoder) Decode() (interface{}, error) {
+	if decoder.remaining == 0 {
+		return nil, ErrCorrupted
+	}
+
+	definition := decoder.definitions[decoder.decoded%uint64(len(decoder.definitions))]
+
+	if definition == nil {
+		return nil, ErrCorrupted
+	}
+
+	payload, err := decoder.readBytes()
+
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := readValue(payload, definition)
+
+	if err != nil {
+		return nil, err
+	}
+
+	decoder.remaining--
+	decoder.decoded++
+
+	if decoder.remaining == 0 && len(decoder.data) > 0 {
+		return nil, ErrCorrupted
+	}
+
+	return value.Interface(), nil
+}
+
+func (decoder *Decoder) readUvarint() (uint64, error) {
+	number, read := binary.Uvarint(decoder.data)
+
+	if read <= 0 {
+		return 0, ErrCorrupted
+	}
+
+	decoder.data = decoder.data[read:]
+
+	return number, nil
+}
+
+func (decoder *Decoder) readBytes() ([]byte, error) {
+	length, err := decoder.readUvarint()
+
+	if err != nil || length > uint64(len(decoder.data)) {
+		return nil, ErrCorrupted
+	}
+
+	read := decoder.data[:length]
+	decoder.data = decoder.data[length:]
+
+	return read, nil
+}
+
+func (decoder *Decoder) readDefinition(target reflect.Type) (reflect.Type, error) {
+	if len(decoder.data) == 0 {
+		return nil, ErrCorrupted
+	}
+
+	kind := reflect.Kind(decoder.data[0])
+	decoder.data = decoder.data[1:]
+
+	name, err := decoder.readBytes()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if kind == reflect.Invalid {
+		if len(name) > 0 {
+			return nil, ErrCorrupted
+		}
+
+		return nil, nil
+	}
+
+	basic, supported := basics[kind]
+
+	if !supported {
+		return nil, ErrCorrupted
+	}
+
+	if target != nil {
+		if target.Kind() != kind {
+			return nil, ErrUnsupportedType
+		}
+
+		return target, nil
+	}
+
+	// Named types can't be built from their name so they must be specified as target
+	if basic.String() != string(name) {
+		return nil, ErrUnsupportedType
+	}
+
+	return basic, nil
+}
+
+// IsSupported checks if the elements of the specified type can be encoded
+func IsSupported(definition reflect.Type) bool {
+	if definition == nil {
+		return true
+	}
+
+	_, supported := basics[definition.Kind()]
+
+	return supported
+}
+
+// NewEncoder instances a new encoder for the specified definitions
+// Elements are expected to cycle through the definitions, so a dictionary
+// would specify its key and value types and encode keys and values alternatively.
+// A nil definition represents an empty structure
+func NewEncoder(definitions ...reflect.Type) (*Encoder, error) {
+	header := append([]byte(magic), Version)
+	header = binary.AppendUvarint(header, uint64(len(definitions)))
+
+	for _, definition := range definitions {
+		if !IsSupported(definition) {
+			return nil, ErrUnsupportedType
+		}
+
+		if definition == nil {
+			header = append(header, byte(reflect.Invalid), 0)
+
+			continue
+		}
+
+		header = append(header, byte(definition.Kind()))
+		header = binary.AppendUvarint(header, uint64(len(definition.String())))
+		header = append(header, definition.String()...)
+	}
+
+	return &Encoder{definitions: definitions, header: header}, nil
+}
+
+// NewDecoder instances a new decoder checking the header and the checksum of the data
+// Targets are the types which every definition will be decoded into and they
+// must have the same kind than the stored ones. Nil targets decode the stored
+// definitions into their unnamed types, failing when they were named types
+func NewDecoder(data []byte, targets ...reflect.Type) (*Decoder, error) {
+	if len(data) < len(magic)+1+checksumSize || string(data[:len(magic)]) != magic {
+		return nil, ErrCorrupted
+	}
+
+	body := data[:len(data)-checksumSize]
+
+	if crc32.ChecksumIEEE(body) != binary.BigEndian.Uint32(data[len(body):]) {
+		return nil, ErrCorrupted
+	}
+
+	if body[len(magic)] != Version {
+		return nil, ErrUnsupportedVersion
+	}
+
+	decoder := &Decoder{data: body[len(magic)+1:]}
+
+	count, err := decoder.readUvarint()
+
+	if err != nil || count != uint64(len(targets)) {
+		return nil, ErrCorrupted
+	}
+
+	for _, target := range targets {
+		definition, err := decoder.readDefinition(target)
+
+		if err != nil {
+			return nil, err
+		}
+
+		decoder.definitions = append(decoder.definitions, definition)
+	}
+
+	if decoder.remaining, err = decoder.readUvarint(); err != nil {
+		return nil, ErrCorrupted
+	}
+
+	if len(targets) == 0 && decoder.remaining > 0 || len(targets) > 0 && decoder.remaining%uint64(len(targets)) != 0 {
+		return nil, ErrCorrupted
+	}
+
+	if decoder.remaining == 0 && len(decoder.data) > 0 {
+		return nil, ErrCorrupted
+	}
+
+	return decoder, nil
+}
+
+func appendValue(data []byte, value reflect.Value) []byte {
+	switch value.Kind() {
+	case reflect.Bool:
+		if value.Bool() {
+			return append(data, 1)
+		}
+
+		return append(data, 0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return binary.AppendVarint(data, value.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return binary.AppendUvarint(data, value.Uint())
+	case reflect.Float32:
+		return binary.BigEndian.AppendUint32(data, math.Float32bits(float32(value.Float())))
+	case reflect.Float64:
+		return binary.BigEndian.AppendUint64(data, math.Float64bits(value.Float()))
+	case reflect.Complex64:
+		data = binary.BigEndian.AppendUint32(data, math.Float32bits(float32(real(value.Complex()))))
+
+		return binary.BigEndian.AppendUint32(data, math.Float32bits(float32(imag(value.Complex()))))
+	case reflect.Complex128:
+		data = binary.BigEndian.AppendUint64(data, math.Float64bits(real(value.Complex())))
+
+		return binary.BigEndian.AppendUint64(data, math.Float64bits(imag(value.Complex())))
+	}
+
+	return append(data, value.String()...)
+}
+
+func readValue(payload []byte, definition reflect.Type) (reflect.Value, error) {
+	value := reflect.New(definition).Elem()
+
+	switch definition.Kind() {
+	case reflect.Bool:
+		if len(payload) != 1 || payload[0] > 1 {
+			return value, ErrCorrupted
+		}
+
+		value.SetBool(payload[0] == 1)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		number, read := binary.Varint(payload)
+
+		if read <= 0 || read != len(payload) || value.OverflowInt(number) {
+			return value, ErrCorrupted
+		}
+
+		value.SetInt(number)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		number, read := binary.Uvarint(payload)
+
+		if read <= 0 || read != len(payload) || value.OverflowUint(number) {
+			return value, ErrCorrupted
+		}
+
+		value.SetUint(number)
+	case reflect.Float32:
+		if len(payload) != 4 {
+			return value, ErrCorrupted
+		}
+
+		value.SetFloat(float64(math.Float32frombits(binary.BigEndian.Uint32(payload))))
+	case reflect.Float64:
+		if len(payload) != 8 {
+			return value, ErrCorrupted
+		}
+
+		value.SetFloat(math.Float64frombits(binary.BigEndian.Uint64(payload)))
+	case reflect.Complex64:
+		if len(payload) != 8 {
+			return value, ErrCorrupted
+		}
+
+		value.SetComplex(complex(
+			float64(math.Float32frombits(binary.BigEndian.Uint32(payload))),
+			float64(math.Float32frombits(binary.BigEndian.Uint32(payload[4:]))),
+		))
+	case reflect.Complex128:
+		if len(payload) != 16 {
+			return value, ErrCorrupted
+		}
+
+		value.SetComplex(complex(
+			math.Float64frombits(binary.BigEndian.Uint64(payload)),
+			math.Float64frombits(binary.BigEndian.Uint64(payload[8:])),
+		))
+	default:
+		value.SetString(string(payload))
+	}
+
+	return value, nil
+}
@@ -0,0 +1,187 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// The datatypes/internal/codec package implements the compact binary format
+// used to serialize the homogeneous structures of datatypes
+
+// This part of package contains the tests for the whole package
+
+package codec
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type celsius float64
+
+func encode(test testing.TB, definitions []reflect.Type, elements ...interface{}) []byte {
+	encoder, err := NewEncoder(definitions...)
+
+	assert.Nil(test, err, "Unexpected error instancing an encoder")
+
+	for _, element := range elements {
+		assert.Nil(test, encoder.Encode(element), "Unexpected error encoding an element")
+	}
+
+	return encoder.Bytes()
+}
+
+func decode(data []byte, targets ...reflect.Type) ([]interface{}, error) {
+	decoder, err := NewDecoder(data, targets...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	elements := []interface{}{}
+
+	for decoder.More() {
+		element, err := decoder.Decode()
+
+		if err != nil {
+			return nil, err
+		}
+
+		elements = append(elements, element)
+	}
+
+	return elements, nil
+}
+
+func TestRoundTrip(test *testing.T) {
+	cases := [][]interface{}{
+		{true, false},
+		{-1, 0, 1 << 40},
+		{int8(-128), int8(127)},
+		{uint16(65535)},
+		{float32(1.5), float32(-0.25)},
+		{3.14, -2.5},
+		{complex64(1 + 2i)},
+		{complex(3, -4)},
+		{"", "hello", "ñandú"},
+	}
+
+	for _, elements := range cases {
+		definition := reflect.TypeOf(elements[0])
+		decoded, err := decode(encode(test, []reflect.Type{definition}, elements...), nil)
+
+		assert.Nil(test, err, "Unexpected error decoding %v", definition)
+		assert.Equal(test, elements, decoded, "Wrong round trip for %v", definition)
+	}
+}
+
+func TestAlternateDefinitions(test *testing.T) {
+	definitions := []reflect.Type{reflect.TypeOf(""), reflect.TypeOf(0)}
+	data := encode(test, definitions, "one", 1, "two", 2)
+
+	decoded, err := decode(data, nil, nil)
+
+	assert.Nil(test, err, "Unexpected error decoding alternate definitions")
+	assert.Equal(test, []interface{}{"one", 1, "two", 2}, decoded, "Wrong elements decoding alternate definitions")
+
+	_, err = decode(data, nil)
+
+	assert.Equal(test, ErrCorrupted, err, "A wrong number of targets should return an error")
+}
+
+func TestEmptyStructures(test *testing.T) {
+	decoded, err := decode(encode(test, []reflect.Type{nil}), reflect.TypeOf(0))
+
+	assert.Nil(test, err, "Unexpected error decoding an empty structure")
+	assert.Empty(test, decoded, "Empty structures shouldn't have elements")
+
+	decoder, _ := NewDecoder(encode(test, []reflect.Type{nil}), reflect.TypeOf(0))
+
+	assert.Equal(test, []reflect.Type{nil}, decoder.Definitions(), "Empty structures shouldn't have definitions")
+}
+
+func TestNamedTypes(test *testing.T) {
+	data := encode(test, []reflect.Type{reflect.TypeOf(celsius(0))}, celsius(36.5))
+
+	_, err := decode(data, nil)
+
+	assert.Equal(test, ErrUnsupportedType, err, "Named types can't be decoded without a target")
+
+	decoded, err := decode(data, reflect.TypeOf(celsius(0)))
+
+	assert.Nil(test, err, "Unexpected error decoding a named type")
+	assert.Equal(test, []interface{}{celsius(36.5)}, decoded, "Wrong elements decoding a named type")
+
+	_, err = decode(data, reflect.TypeOf(""))
+
+	assert.Equal(test, ErrUnsupportedType, err, "Targets of a different kind should return an error")
+}
+
+func TestUnsupportedTypes(test *testing.T) {
+	_, err := NewEncoder(reflect.TypeOf(struct{}{}))
+
+	assert.Equal(test, ErrUnsupportedType, err, "Non primitive types can't be encoded")
+
+	encoder, _ := NewEncoder(reflect.TypeOf(0))
+
+	assert.Equal(test, ErrUnsupportedType, encoder.Encode("string"), "Elements of another type can't be encoded")
+}
+
+func TestCorruptedData(test *testing.T) {
+	data := encode(test, []reflect.Type{reflect.TypeOf(0)}, 1, 2, 3)
+
+	for position := range data {
+		corrupted := append([]byte(nil), data...)
+		corrupted[position] ^= 0xFF
+
+		_, err := decode(corrupted, nil)
+
+		assert.Error(test, err, "Corrupted byte %d should return an error", position)
+	}
+
+	_, err := decode(data[:len(data)-1], nil)
+
+	assert.Equal(test, ErrCorrupted, err, "Truncated data should return an error")
+
+	future := append([]byte(nil), data...)
+	future[len(magic)] = Version + 1
+
+	_, err = decode(Seal(future), nil)
+
+	assert.Equal(test, ErrUnsupportedVersion, err, "Unknown versions should return an error")
+}
+
+func FuzzDecode(fuzz *testing.F) {
+	fuzz.Add(encode(fuzz, []reflect.Type{reflect.TypeOf(0)}, 1, 2, 3))
+	fuzz.Add(encode(fuzz, []reflect.Type{reflect.TypeOf("")}, "a", "b"))
+	fuzz.Add(encode(fuzz, []reflect.Type{nil}))
+	fuzz.Add([]byte(magic))
+
+	fuzz.Fuzz(func(test *testing.T, data []byte) {
+		// Any input must be either decoded or rejected without panicking,
+		// so it's decoded as well with a valid checksum to reach the payload parsing
+		for _, input := range [][]byte{data, Seal(data)} {
+			decode(input, nil)
+			decode(input, reflect.TypeOf(celsius(0)))
+			decode(input, nil, nil)
+		}
+	})
+}
+
+func FuzzRoundTrip(fuzz *testing.F) {
+	fuzz.Add("hello", int64(-7), 2.5)
+
+	fuzz.Fuzz(func(test *testing.T, text string, number int64, float float64) {
+		definitions := []reflect.Type{reflect.TypeOf(""), reflect.TypeOf(int64(0))}
+		decoded, err := decode(encode(test, definitions, text, number), nil, nil)
+
+		assert.Nil(test, err, "Unexpected error decoding valid data")
+		assert.Equal(test, []interface{}{text, number}, decoded, "Wrong round trip of valid data")
+
+		floats := encode(test, []reflect.Type{reflect.TypeOf(0.0)}, float)
+		decodedFloats, err := decode(floats, nil)
+
+		assert.Nil(test, err, "Unexpected error decoding valid floats")
+		assert.Equal(test, math.Float64bits(float), math.Float64bits(decodedFloats[0].(float64)), "Wrong round trip of valid floats")
+	})
+}
@@ -0,0 +1,21 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// The datatypes/internal/codec package implements the compact binary format
+// used to serialize the homogeneous structures of datatypes
+
+// This part of package contains the typped errors that the package uses
+
+package codec
+
+import "errors"
+
+// ErrCorrupted represents an error for malformed, truncated or tampered data
+var ErrCorrupted = errors.New("Corrupted data: invalid binary format or checksum")
+
+// ErrUnsupportedVersion represents an error for data written by an unknown version of the format
+var ErrUnsupportedVersion = errors.New("Unsupported binary format version")
+
+// ErrUnsupportedType represents an error for types which the binary format can't represent
+var ErrUnsupportedType = errors.New("Unsupported type: only booleans, numbers and strings can be encoded")
@@ -0,0 +1,373 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// The datatypes/internal/hamt package implements a persistent hash array mapped trie,
+// an immutable map whose versions share most of their structure between them
+
+// This part of package contains the core behaviour
+
+package hamt
+
+import "math/bits"
+
+const (
+	// bitsPerLevel is the number of hash bits consumed on every level of the trie
+	bitsPerLevel = 5
+	mask         = 1<<bitsPerLevel - 1
+	// maxShift is the shift from which the whole hash has been consumed and
+	// the nodes become lists of colliding entries
+	maxShift = 64
+)
+
+// owner identifies the transient which is allowed to mutate a node in place
+// It can't be a zero-sized type as their pointers aren't guaranteed to be different
+type owner struct {
+	_ byte
+}
+
+type entry struct {
+	hash  uint64
+	key   interface{}
+	value interface{}
+	node  *node
+}
+
+type node struct {
+	bitmap  uint32
+	entries []entry
+	owner   *owner
+}
+
+// Map represents an immutable map
+// Every modification returns a new map which shares its unchanged nodes with the original
+type Map struct {
+	root  *node
+	size  int
+	hash  func(interface{}) uint64
+	equal func(interface{}, interface{}) bool
+}
+
+// Len returns the number of entries
+func (m *Map) Len() int {
+	return m.size
+}
+
+// Get returns the value stored under the specified key
+func (m *Map) Get(key interface{}) (interface{}, bool) {
+	if m.root == nil {
+		return nil, false
+	}
+
+	return m.root.get(m.equal, m.hash(key), 0, key)
+}
+
+// Set returns a new map storing the value under the specified key
+func (m *Map) Set(key interface{}, value interface{}) *Map {
+	root, added := m.insert(nil, key, value)
+
+	return &Map{root: root, size: m.size + added, hash: m.hash, equal: m.equal}
+}
+
+// Delete returns a new map without the specified key
+// If the key doesn't exist the same map is returned
+func (m *Map) Delete(key interface{}) *Map {
+	root, removed := m.remove(nil, key)
+
+	if !removed {
+		return m
+	}
+
+	return &Map{root: root, size: m.size - 1, hash: m.hash, equal: m.equal}
+}
+
+// Range calls the specified function for every entry until it returns false
+// The order of the entries is determined by their hashes
+func (m *Map) Range(f func(key interface{}, value interface{}) bool) {
+	if m.root != nil {
+		m.root.walk(f)
+	}
+}
+
+// Transient returns a mutable copy of the map intended for bulk modifications
+// The map itself is not modified and both share their structure
+func (m *Map) Transient() *Transient {
+	return &Transient{
+		Map:   Map{root: m.root, size: m.size, hash: m.hash, equal: m.equal},
+		owner: new(owner),
+	}
+}
+
+func (m *Map) insert(owner *owner, key interface{}, value interface{}) (*node, int) {
+	hash := m.hash(key)
+
+	if m.root == nil {
+		return &node{
+			bitmap:  bitFor(hash, 0),
+			entries: []entry{{hash: hash, key: key, value: value}},
+			owner:   owner,
+		}, 1
+	}
+
+	root, added := m.root.set(m.equal, owner, hash, 0, key, value)
+
+	if added {
+		return root, 1
+	}
+
+	return root, 0
+}
+
+func (m *Map) remove(owner *owner, key interface{}) (*node, bool) {
+	if m.root == nil {
+		return nil, false
+	}
+
+	return m.root.delete(m.equal, owner, m.hash(key), 0, key)
+}
+
+// Transient represents a mutable map which modifies in place the nodes it owns
+// It must not be used concurrently
+type Transient struct {
+	Map
+	owner *owner
+}
+
+// Set stores the value under the specified key
+func (transient *Transient) Set(key interface{}, value interface{}) {
+	root, added := transient.insert(transient.owner, key, value)
+
+	transient.root = root
+	transient.size += added
+}
+
+// Delete removes the specified key, reporting if it existed
+func (transient *Transient) Delete(key interface{}) bool {
+	root, removed := transient.remove(transient.owner, key)
+
+	if removed {
+		transient.root = root
+		transient.size--
+	}
+
+	return removed
+}
+
+// Persistent returns an immutable map with the current entries
+// The transient can still be used afterwards without affecting the returned map
+func (transient *Transient) Persistent() *Map {
+	transient.owner = new(owner)
+
+	return &Map{root: transient.root, size: transient.size, hash: transient.hash, equal: transient.equal}
+}
+
+func (n *node) get(equal func(interface{}, interface{}) bool, hash uint64, shift uint, key interface{}) (interface{}, bool) {
+	if shift >= maxShift {
+		for _, current := range n.entries {
+			if equal(current.key, key) {
+				return current.value, true
+			}
+		}
+
+		return nil, false
+	}
+
+	bit := bitFor(hash, shift)
+
+	if n.bitmap&bit == 0 {
+		return nil, false
+	}
+
+	current := n.entries[n.position(bit)]
+
+	if current.node != nil {
+		return current.node.get(equal, hash, shift+bitsPerLevel, key)
+	}
+
+	if current.hash == hash && equal(current.key, key) {
+		return current.value, true
+	}
+
+	return nil, false
+}
+
+func (n *node) set(equal func(interface{}, interface{}) bool, owner *owner, hash uint64, shift uint, key interface{}, value interface{}) (*node, bool) {
+	if shift >= maxShift {
+		for position, current := range n.entries {
+			if equal(current.key, key) {
+				edited := n.editable(owner)
+				edited.entries[position].value = value
+
+				return edited, false
+			}
+		}
+
+		edited := n.editable(owner)
+		edited.entries = append(edited.entries, entry{hash: hash, key: key, value: value})
+
+		return edited, true
+	}
+
+	bit := bitFor(hash, shift)
+	position := n.position(bit)
+
+	if n.bitmap&bit == 0 {
+		edited := n.editable(owner)
+		edited.bitmap |= bit
+		edited.entries = append(edited.entries, entry{})
+		copy(edited.entries[position+1:], edited.entries[position:])
+		edited.entries[position] = entry{hash: hash, key: key, value: value}
+
+		return edited, true
+	}
+
+	current := n.entries[position]
+
+	if current.node != nil {
+		child, added := current.node.set(equal, owner, hash, shift+bitsPerLevel, key, value)
+		edited := n.editable(owner)
+		edited.entries[position].node = child
+
+		return edited, added
+	}
+
+	edited := n.editable(owner)
+
+	if current.hash == hash && equal(current.key, key) {
+		edited.entries[position].value = value
+
+		return edited, false
+	}
+
+	edited.entries[position] = entry{node: branch(owner, shift+bitsPerLevel, current, entry{hash: hash, key: key, value: value})}
+
+	return edited, true
+}
+
+func (n *node) delete(equal func(interface{}, interface{}) bool, owner *owner, hash uint64, shift uint, key interface{}) (*node, bool) {
+	if shift >= maxShift {
+		for position, current := range n.entries {
+			if !equal(current.key, key) {
+				continue
+			}
+
+			if len(n.entries) == 1 {
+				return nil, true
+			}
+
+			edited := n.editable(owner)
+			edited.entries = append(edited.entries[:position], edited.entries[position+1:]...)
+
+			return edited, true
+		}
+
+		return n, false
+	}
+
+	bit := bitFor(hash, shift)
+
+	if n.bitmap&bit == 0 {
+		return n, false
+	}
+
+	position := n.position(bit)
+	current := n.entries[position]
+
+	if current.node != nil {
+		child, removed := current.node.delete(equal, owner, hash, shift+bitsPerLevel, key)
+
+		if !removed {
+			return n, false
+		}
+
+		if child != nil {
+			edited := n.editable(owner)
+
+			// Nodes with a single entry are pulled up so the trie stays compact
+			if len(child.entries) == 1 && child.entries[0].node == nil {
+				edited.entries[position] = child.entries[0]
+			} else {
+				edited.entries[position].node = child
+			}
+
+			return edited, true
+		}
+	} else if current.hash != hash || !equal(current.key, key) {
+		return n, false
+	}
+
+	if len(n.entries) == 1 {
+		return nil, true
+	}
+
+	edited := n.editable(owner)
+	edited.bitmap &^= bit
+	edited.entries = append(edited.entries[:position], edited.entries[position+1:]...)
+
+	return edited, true
+}
+
+func (n *node) walk(f func(interface{}, interface{}) bool) bool {
+	for _, current := range n.entries {
+		if current.node != nil {
+			if !current.node.walk(f) {
+				return false
+			}
+		} else if !f(current.key, current.value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// editable returns the node itself if it's owned by the specified transient,
+// otherwise a copy of it which is owned by the transient
+func (n *node) editable(owner *owner) *node {
+	if owner != nil && n.owner == owner {
+		return n
+	}
+
+	return &node{
+		bitmap:  n.bitmap,
+		entries: append(make([]entry, 0, len(n.entries)+1), n.entries...),
+		owner:   owner,
+	}
+}
+
+func (n *node) position(bit uint32) int {
+	return bits.OnesCount32(n.bitmap & (bit - 1))
+}
+
+// branch returns a new node storing two entries whose hashes are equal until the specified shift
+func branch(owner *owner, shift uint, first entry, second entry) *node {
+	if shift >= maxShift {
+		return &node{entries: []entry{first, second}, owner: owner}
+	}
+
+	firstBit, secondBit := bitFor(first.hash, shift), bitFor(second.hash, shift)
+
+	if firstBit == secondBit {
+		return &node{
+			bitmap:  firstBit,
+			entries: []entry{{node: branch(owner, shift+bitsPerLevel, first, second)}},
+			owner:   owner,
+		}
+	}
+
+	if firstBit > secondBit {
+		first, second = second, first
+	}
+
+	return &node{bitmap: firstBit | secondBit, entries: []entry{first, second}, owner: owner}
+}
+
+func bitFor(hash uint64, shift uint) uint32 {
+	return 1 << ((hash >> shift) & mask)
+}
+
+// New instances a new empty map which uses the specified functions to hash
+// and compare its keys, so keys considered equal must have the same hash
+func New(hash func(interface{}) uint64, equal func(interface{}, interface{}) bool) *Map {
+	return &Map{hash: hash, equal: equal}
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// The datatypes/internal/hamt package implements a persistent hash array mapped trie,
+// an immutable map whose versions share most of their structure between them
+
+// This part of package contains the tests for the whole package
+
+package hamt
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func intHash(key interface{}) uint64 {
+	return uint64(key.(int)) * 11400714819323198485
+}
+
+// collidingHash only uses a few bits so many keys share the same hash
+func collidingHash(key interface{}) uint64 {
+	return uint64(key.(int) % 4)
+}
+
+func equal(first interface{}, second interface{}) bool {
+	return first == second
+}
+
+func entries(m *Map) map[interface{}]interface{} {
+	result := make(map[interface{}]interface{})
+
+	m.Range(func(key interface{}, value interface{}) bool {
+		result[key] = value
+
+		return true
+	})
+
+	return result
+}
+
+func TestSetAndGet(test *testing.T) {
+	empty := New(intHash, equal)
+	first := empty.Set(1, "one")
+	second := first.Set(2, "two").Set(1, "uno")
+
+	value, exists := second.Get(1)
+
+	assert.True(test, exists, "Stored keys should exist")
+	assert.Equal(test, "uno", value, "Wrong value of a replaced key")
+	assert.Equal(test, 2, second.Len(), "Replacing a key shouldn't change the length")
+
+	value, _ = first.Get(1)
+
+	assert.Equal(test, "one", value, "Previous versions shouldn't be modified")
+	assert.Equal(test, 0, empty.Len(), "Previous versions shouldn't be modified")
+
+	_, exists = second.Get(3)
+
+	assert.False(test, exists, "Non stored keys shouldn't exist")
+}
+
+func TestDelete(test *testing.T) {
+	original := New(intHash, equal).Set(1, "one").Set(2, "two")
+	deleted := original.Delete(1)
+
+	assert.Equal(test, map[interface{}]interface{}{2: "two"}, entries(deleted), "Wrong entries after deleting a key")
+	assert.Equal(test, 2, original.Len(), "Previous versions shouldn't be modified")
+	assert.Same(test, deleted, deleted.Delete(3), "Deleting a non stored key should return the same map")
+	assert.Equal(test, 0, deleted.Delete(2).Len(), "Wrong length after deleting every key")
+}
+
+func TestTransient(test *testing.T) {
+	original := New(intHash, equal).Set(1, "one")
+	transient := original.Transient()
+
+	for number := 0; number < 100; number++ {
+		transient.Set(number, number)
+	}
+
+	assert.True(test, transient.Delete(50), "Deleting a stored key should be reported")
+	assert.False(test, transient.Delete(500), "Deleting a non stored key should be reported")
+
+	persistent := transient.Persistent()
+	transient.Set(200, 200)
+
+	assert.Equal(test, 99, persistent.Len(), "Wrong length of the persistent map")
+	assert.Equal(test, 100, transient.Len(), "Wrong length of the transient after being persisted")
+	assert.Equal(test, map[interface{}]interface{}{1: "one"}, entries(original), "Transients shouldn't modify the original map")
+
+	_, exists := persistent.Get(200)
+
+	assert.False(test, exists, "Transients shouldn't modify the persisted maps")
+}
+
+func TestRandomOperations(test *testing.T) {
+	for _, hash := range []func(interface{}) uint64{intHash, collidingHash} {
+		random := rand.New(rand.NewSource(1))
+		expected := make(map[interface{}]interface{})
+		versions := []*Map{New(hash, equal)}
+		snapshots := []map[interface{}]interface{}{{}}
+
+		for operation := 0; operation < 2000; operation++ {
+			current := versions[len(versions)-1]
+			key := random.Intn(200)
+
+			if random.Intn(3) == 0 {
+				current = current.Delete(key)
+				delete(expected, key)
+			} else {
+				current = current.Set(key, operation)
+				expected[key] = operation
+			}
+
+			assert.Equal(test, len(expected), current.Len(), "Wrong length after random operations")
+
+			versions = append(versions, current)
+			snapshot := make(map[interface{}]interface{}, len(expected))
+
+			for key, value := range expected {
+				snapshot[key] = value
+			}
+
+			snapshots = append(snapshots, snapshot)
+		}
+
+		for version, current := range versions {
+			assert.Equal(test, snapshots[version], entries(current), "Versions should keep their entries")
+		}
+	}
+}
+
+func BenchmarkSet(benchmark *testing.B) {
+	m := New(intHash, equal)
+
+	for x := 0; x < benchmark.N; x++ {
+		m = m.Set(x, x)
+	}
+}
+
+func BenchmarkTransientSet(benchmark *testing.B) {
+	transient := New(intHash, equal).Transient()
+
+	for x := 0; x < benchmark.N; x++ {
+		transient.Set(x, x)
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// The datatypes/internal/observer package implements the change notifications
+// shared by the structures of datatypes
+
+// This part of package contains the core behaviour
+
+package observer
+
+// Hub delivers events to its subscribed listeners
+// Events notified while a batch is open are delivered together when it's closed.
+// A nil hub ignores every notification so structures without listeners don't pay for them
+type Hub[E any] struct {
+	listeners []listener[E]
+	next      int
+	depth     int
+	pending   []E
+}
+
+type listener[E any] struct {
+	id int
+	f  func([]E)
+}
+
+// noop is returned as the closing function of the batches of nil hubs
+func noop() {}
+
+// Subscribe registers a listener and returns the function which unsubscribes it
+func (hub *Hub[E]) Subscribe(f func([]E)) func() {
+	hub.next++
+	id := hub.next
+
+	hub.listeners = append(hub.listeners, listener[E]{id: id, f: f})
+
+	return func() {
+		for position, current := range hub.listeners {
+			if current.id == id {
+				hub.listeners = append(hub.listeners[:position:position], hub.listeners[position+1:]...)
+
+				return
+			}
+		}
+	}
+}
+
+// Batch opens a batch and returns the function which closes it
+// Batches can be nested and the events are delivered when the outermost one is closed
+func (hub *Hub[E]) Batch() func() {
+	if hub == nil {
+		return noop
+	}
+
+	hub.depth++
+
+	return func() {
+		hub.depth--
+		hub.flush()
+	}
+}
+
+// Notify delivers the event or keeps it until the open batch is closed
+func (hub *Hub[E]) Notify(event E) {
+	if hub == nil || len(hub.listeners) == 0 {
+		return
+	}
+
+	hub.pending = append(hub.pending, event)
+	hub.flush()
+}
+
+func (hub *Hub[E]) flush() {
+	if hub.depth > 0 || len(hub.pending) == 0 {
+		return
+	}
+
+	events := hub.pending
+	hub.pending = nil
+
+	// Listeners are copied so they can unsubscribe while the events are delivered
+	for _, current := range append([]listener[E](nil), hub.listeners...) {
+		current.f(events)
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// The datatypes/internal/observer package implements the change notifications
+// shared by the structures of datatypes
+
+// This part of package contains the tests for the whole package
+
+package observer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotify(test *testing.T) {
+	hub := new(Hub[int])
+	received := [][]int{}
+
+	unsubscribe := hub.Subscribe(func(events []int) {
+		received = append(received, events)
+	})
+
+	hub.Notify(1)
+	hub.Notify(2)
+	unsubscribe()
+	hub.Notify(3)
+
+	assert.Equal(test, [][]int{{1}, {2}}, received, "Wrong delivered events")
+}
+
+func TestBatch(test *testing.T) {
+	hub := new(Hub[int])
+	received := [][]int{}
+
+	hub.Subscribe(func(events []int) {
+		received = append(received, events)
+	})
+
+	end := hub.Batch()
+	hub.Notify(1)
+
+	nested := hub.Batch()
+	hub.Notify(2)
+	nested()
+
+	assert.Empty(test, received, "Events shouldn't be delivered while a batch is open")
+
+	end()
+
+	assert.Equal(test, [][]int{{1, 2}}, received, "Batched events should be delivered together")
+
+	hub.Batch()()
+
+	assert.Len(test, received, 1, "Empty batches shouldn't be delivered")
+}
+
+func TestNilHub(test *testing.T) {
+	var hub *Hub[int]
+
+	assert.NotPanics(test, func() {
+		end := hub.Batch()
+		hub.Notify(1)
+		end()
+	}, "Nil hubs should ignore notifications")
+}
+
+func TestUnsubscribeWhileDelivering(test *testing.T) {
+	hub := new(Hub[int])
+	calls := 0
+
+	var unsubscribe func()
+
+	unsubscribe = hub.Subscribe(func([]int) {
+		calls++
+		unsubscribe()
+	})
+
+	hub.Subscribe(func([]int) {
+		calls++
+	})
+
+	hub.Notify(1)
+	hub.Notify(2)
+
+	assert.Equal(test, 3, calls, "Listeners should be able to unsubscribe while being notified")
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// The datatypes/internal/ranges package implements the options of the range
+// operations shared by the structures of datatypes
+
+// This part of package contains the core behaviour
+
+package ranges
+
+import "errors"
+
+// Option configures the behaviour of a range operation
+type Option func(*settings)
+
+type settings struct {
+	join bool
+}
+
+// JoinErrors makes the range operation check all the elements and return
+// every failure joined with errors.Join instead of stopping at the first one
+func JoinErrors() Option {
+	return func(options *settings) {
+		options.join = true
+	}
+}
+
+// Failures collects the errors found by a range operation
+type Failures struct {
+	settings
+	errors []error
+}
+
+// NewFailures instances a new collector configured by the specified options
+func NewFailures(options []Option) *Failures {
+	result := new(Failures)
+
+	for _, option := range options {
+		option(&result.settings)
+	}
+
+	return result
+}
+
+// Add collects the error returning true if the operation should keep checking elements
+func (result *Failures) Add(err error) bool {
+	result.errors = append(result.errors, err)
+
+	return result.join
+}
+
+// Err returns the first collected error or all of them joined
+func (result *Failures) Err() error {
+	if len(result.errors) == 0 {
+		return nil
+	}
+
+	if !result.join {
+		return result.errors[0]
+	}
+
+	return errors.Join(result.errors...)
+}
@@ -0,0 +1,38 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// The datatypes/internal/ranges package implements the options of the range
+// operations shared by the structures of datatypes
+
+// This part of package contains the tests for the whole package
+
+package ranges
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	errFirst  = errors.New("first")
+	errSecond = errors.New("second")
+)
+
+func TestFailures(test *testing.T) {
+	failures := NewFailures(nil)
+
+	assert.Nil(test, failures.Err(), "Failures without errors shouldn't return an error")
+	assert.False(test, failures.Add(errFirst), "Failures without options should stop at the first error")
+	assert.Equal(test, errFirst, failures.Err(), "Failures without options should return the first error")
+}
+
+func TestJoinErrors(test *testing.T) {
+	failures := NewFailures([]Option{JoinErrors()})
+
+	assert.True(test, failures.Add(errFirst), "JoinErrors option should keep checking after an error")
+	assert.True(test, failures.Add(errSecond), "JoinErrors option should keep checking after an error")
+	assert.Equal(test, errors.Join(errFirst, errSecond), failures.Err(), "JoinErrors option should return all the errors joined")
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// The datatypes/internal/sqltest package provides an in-memory database/sql
+// driver used to test the database integration of datatypes
+
+// This part of package contains the core behaviour
+
+package sqltest
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+)
+
+// ErrUnsupported represents an error for operations the driver doesn't implement
+var ErrUnsupported = errors.New("Unsupported operation on the in-memory driver")
+
+// Driver is an in-memory driver which stores a single value per key
+// Exec statements store their second argument under the first one and
+// Query statements return the value stored under their first argument
+// The text of the statements is ignored
+type Driver struct {
+	mutex  sync.Mutex
+	values map[driver.Value]driver.Value
+}
+
+// Open returns a new connection to the in-memory storage
+func (drv *Driver) Open(name string) (driver.Conn, error) {
+	return &conn{driver: drv}, nil
+}
+
+// Connect returns a new connection to the in-memory storage
+func (drv *Driver) Connect(ctx context.Context) (driver.Conn, error) {
+	return drv.Open("")
+}
+
+// Driver returns the driver itself
+func (drv *Driver) Driver() driver.Driver {
+	return drv
+}
+
+type conn struct {
+	driver *Driver
+}
+
+func (cn *conn) Prepare(query string) (driver.Stmt, error) {
+	return &stmt{driver: cn.driver}, nil
+}
+
+func (cn *conn) Close() error {
+	return nil
+}
+
+func (cn *conn) Begin() (driver.Tx, error) {
+	return nil, ErrUnsupported
+}
+
+type stmt struct {
+	driver *Driver
+}
+
+func (st *stmt) Close() error {
+	return nil
+}
+
+func (st *stmt) NumInput() int {
+	return -1
+}
+
+func (st *stmt) Exec(args []driver.Value) (driver.Result, error) {
+	if len(args) != 2 {
+		return nil, ErrUnsupported
+	}
+
+	st.driver.mutex.Lock()
+	defer st.driver.mutex.Unlock()
+
+	st.driver.values[args[0]] = args[1]
+
+	return driver.RowsAffected(1), nil
+}
+
+func (st *stmt) Query(args []driver.Value) (driver.Rows, error) {
+	if len(args) != 1 {
+		return nil, ErrUnsupported
+	}
+
+	st.driver.mutex.Lock()
+	defer st.driver.mutex.Unlock()
+
+	value, exists := st.driver.values[args[0]]
+
+	return &rows{value: value, pending: exists}, nil
+}
+
+type rows struct {
+	value   driver.Value
+	pending bool
+}
+
+func (rs *rows) Columns() []string {
+	return []string{"value"}
+}
+
+func (rs *rows) Close() error {
+	return nil
+}
+
+func (rs *rows) Next(dest []driver.Value) error {
+	if !rs.pending {
+		return io.EOF
+	}
+
+	dest[0] = rs.value
+	rs.pending = false
+
+	return nil
+}
+
+// Open instances a new database backed by an empty in-memory storage
+func Open() *sql.DB {
+	return sql.OpenDB(&Driver{values: make(map[driver.Value]driver.Value)})
+}
@@ -0,0 +1,256 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// The datatypes/stream package provides lazy pipelines over collections,
+// dictionaries and any other sequence of elements, so the operations are
+// evaluated on demand without materializing intermediate lists.
+
+// This part of package contains the core behaviour
+
+package stream
+
+import (
+	"iter"
+	"reflect"
+
+	"github.com/jaimelopez/datatypes/collection"
+	"github.com/jaimelopez/datatypes/dictionary"
+	"github.com/jaimelopez/datatypes/generic"
+)
+
+// Stream represents a lazy sequence of elements
+// Operators return new streams and nothing is evaluated until the stream is consumed
+type Stream struct {
+	seq iter.Seq[collection.Element]
+}
+
+// Filter returns a stream with only the elements which satisfy the specified function
+func (stream *Stream) Filter(f func(collection.Element) bool) *Stream {
+	return Of(func(yield func(collection.Element) bool) {
+		for element := range stream.seq {
+			if f(element) && !yield(element) {
+				return
+			}
+		}
+	})
+}
+
+// Map returns a stream with the results of applying the function to every element
+func (stream *Stream) Map(f func(collection.Element) collection.Element) *Stream {
+	return Of(func(yield func(collection.Element) bool) {
+		for element := range stream.seq {
+			if !yield(f(element)) {
+				return
+			}
+		}
+	})
+}
+
+// Take returns a stream with the first n elements
+// The source is not consumed beyond these elements
+func (stream *Stream) Take(n int) *Stream {
+	return Of(func(yield func(collection.Element) bool) {
+		if n <= 0 {
+			return
+		}
+
+		taken := 0
+
+		for element := range stream.seq {
+			if !yield(element) {
+				return
+			}
+
+			if taken++; taken == n {
+				return
+			}
+		}
+	})
+}
+
+// Skip returns a stream without the first n elements
+func (stream *Stream) Skip(n int) *Stream {
+	return Of(func(yield func(collection.Element) bool) {
+		skipped := 0
+
+		for element := range stream.seq {
+			if skipped < n {
+				skipped++
+
+				continue
+			}
+
+			if !yield(element) {
+				return
+			}
+		}
+	})
+}
+
+// Distinct returns a stream without repeated elements
+// Elements are compared with generic.Equal as collections do
+func (stream *Stream) Distinct() *Stream {
+	return Of(func(yield func(collection.Element) bool) {
+		seen := make(map[uint64][]collection.Element)
+
+		for element := range stream.seq {
+			hash := generic.Hash(element)
+
+			if containsDeeply(seen[hash], element) {
+				continue
+			}
+
+			seen[hash] = append(seen[hash], element)
+
+			if !yield(element) {
+				return
+			}
+		}
+	})
+}
+
+// Window returns a stream of sliding windows of the specified size
+// Every window is a []collection.Element with the element and its predecessors,
+// so the first window is emitted once there are enough elements
+func (stream *Stream) Window(size int) *Stream {
+	return Of(func(yield func(collection.Element) bool) {
+		if size <= 0 {
+			return
+		}
+
+		window := make([]collection.Element, 0, size)
+
+		for element := range stream.seq {
+			if len(window) == size {
+				window = window[1:]
+			}
+
+			window = append(window, element)
+
+			if len(window) == size && !yield(append([]collection.Element(nil), window...)) {
+				return
+			}
+		}
+	})
+}
+
+// Seq returns the stream as an iterator
+func (stream *Stream) Seq() iter.Seq[collection.Element] {
+	return stream.seq
+}
+
+// ForEach consumes the stream calling the specified function for every element
+func (stream *Stream) ForEach(f func(collection.Element)) {
+	for element := range stream.seq {
+		f(element)
+	}
+}
+
+// Count consumes the stream and returns the number of elements
+func (stream *Stream) Count() int {
+	count := 0
+
+	for range stream.seq {
+		count++
+	}
+
+	return count
+}
+
+// ToSlice consumes the stream and returns its elements as a slice
+func (stream *Stream) ToSlice() []collection.Element {
+	elements := []collection.Element{}
+
+	for element := range stream.seq {
+		elements = append(elements, element)
+	}
+
+	return elements
+}
+
+// ToCollection consumes the stream and stores its elements in a new collection
+// It follows the same rules than the Add method of Collection, so the
+// elements must be unique and homogeneous
+func (stream *Stream) ToCollection() (*collection.Collection, error) {
+	col := collection.NewEmptyCollection()
+
+	for element := range stream.seq {
+		if err := col.Add(element); err != nil {
+			return nil, err
+		}
+	}
+
+	return col, nil
+}
+
+// ToDictionary consumes the stream and stores its elements in a new dictionary
+// The elements must be dictionary.KeyValueElement values, otherwise it returns an error
+func (stream *Stream) ToDictionary() (*dictionary.Dictionary, error) {
+	dic := dictionary.NewEmptyDictionary()
+
+	for element := range stream.seq {
+		pair, ok := element.(dictionary.KeyValueElement)
+
+		if !ok {
+			return nil, dictionary.ErrInvalidKeyValueElementType
+		}
+
+		if err := dic.AddKeyValueElement(pair); err != nil {
+			return nil, err
+		}
+	}
+
+	return dic, nil
+}
+
+func containsDeeply(elements []collection.Element, element collection.Element) bool {
+	for _, current := range elements {
+		if generic.Equal(current, element) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Of instances a new stream over the specified iterator
+func Of(seq iter.Seq[collection.Element]) *Stream {
+	return &Stream{seq: seq}
+}
+
+// FromSlice instances a new stream over the elements of a slice
+// If the parameter is not a slice it returns a generic.NotIterableError.
+// The slice isn't copied, its elements are read as the stream is consumed
+func FromSlice(elements collection.ElementList) (*Stream, error) {
+	slice := reflect.ValueOf(elements)
+
+	if slice.Kind() != reflect.Slice {
+		return nil, &generic.NotIterableError{Type: reflect.TypeOf(elements)}
+	}
+
+	return Of(func(yield func(collection.Element) bool) {
+		for x := 0; x < slice.Len(); x++ {
+			if !yield(slice.Index(x).Interface()) {
+				return
+			}
+		}
+	}), nil
+}
+
+// FromCollection instances a new stream over the elements of a collection
+func FromCollection(col *collection.Collection) *Stream {
+	return Of(col.Values())
+}
+
+// FromDictionary instances a new stream over the elements of a dictionary
+// as dictionary.KeyValueElement values
+func FromDictionary(dic *dictionary.Dictionary) *Stream {
+	return Of(func(yield func(collection.Element) bool) {
+		for key, value := range dic.Iter() {
+			if !yield(dictionary.KeyValueElement{Key: key, Value: value}) {
+				return
+			}
+		}
+	})
+}
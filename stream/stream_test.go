@@ -0,0 +1,109 @@
+// Copyright (c) 2017 Jaime Lopez. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+// The datatypes/stream package provides lazy pipelines over collections,
+// dictionaries and any other sequence of elements, so the operations are
+// evaluated on demand without materializing intermediate lists.
+
+// This part of package contains the tests for the whole package
+
+package stream
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jaimelopez/datatypes/collection"
+	"github.com/jaimelopez/datatypes/dictionary"
+	"github.com/jaimelopez/datatypes/generic"
+	"github.com/stretchr/testify/assert"
+)
+
+// naturals returns an infinite stream of natural numbers counting the consumed ones
+func naturals(consumed *int) *Stream {
+	return Of(func(yield func(collection.Element) bool) {
+		for number := 0; ; number++ {
+			*consumed++
+
+			if !yield(number) {
+				return
+			}
+		}
+	})
+}
+
+func TestLazyEvaluation(test *testing.T) {
+	consumed := 0
+
+	result := naturals(&consumed).
+		Filter(func(element collection.Element) bool { return element.(int)%2 == 0 }).
+		Map(func(element collection.Element) collection.Element { return element.(int) * 10 }).
+		Take(3)
+
+	assert.Zero(test, consumed, "Streams shouldn't be evaluated until they're consumed")
+	assert.Equal(test, []collection.Element{0, 20, 40}, result.ToSlice(), "Wrong elements on a lazy pipeline")
+	assert.Equal(test, 5, consumed, "Streams should consume only the needed elements")
+}
+
+func TestSkipAndDistinctOperators(test *testing.T) {
+	stream, err := FromSlice([]int{1, 1, 2, 3, 2, 4})
+
+	assert.Nil(test, err, "Unexpected error instancing a stream from a slice")
+	assert.Equal(test, []collection.Element{2, 3, 4}, stream.Distinct().Skip(1).ToSlice(), "Wrong elements on Distinct and Skip operators")
+
+	_, err = FromSlice("non-iterable")
+
+	assert.Equal(test, &generic.NotIterableError{Type: reflect.TypeOf("")}, err, "Non-iterable elements should return an error")
+}
+
+func TestFromSliceReadsTheSliceLazily(test *testing.T) {
+	elements := []int{1, 2, 3}
+	stream, _ := FromSlice(elements)
+
+	elements[0] = 10
+
+	assert.Equal(test, []collection.Element{10, 2, 3}, stream.ToSlice(), "FromSlice shouldn't copy the slice before consuming it")
+}
+
+func TestWindowOperator(test *testing.T) {
+	stream, _ := FromSlice([]int{1, 2, 3, 4})
+
+	windows := stream.Window(3).ToSlice()
+
+	assert.Equal(test, []collection.Element{
+		[]collection.Element{1, 2, 3},
+		[]collection.Element{2, 3, 4},
+	}, windows, "Wrong sliding windows on Window operator")
+}
+
+func TestCollectionStreams(test *testing.T) {
+	source := collection.NewCollection([]int{1, 2, 3, 4})
+
+	result, err := FromCollection(source).
+		Filter(func(element collection.Element) bool { return element.(int) > 2 }).
+		ToCollection()
+
+	assert.Nil(test, err, "Unexpected error collecting a stream into a collection")
+	assert.Equal(test, []collection.Element{3, 4}, result.Elements(), "Wrong elements collecting a stream into a collection")
+	assert.Equal(test, 4, FromCollection(source).Count(), "Wrong number of elements on Count method")
+
+	_, err = FromCollection(source).Map(func(collection.Element) collection.Element { return 1 }).ToCollection()
+
+	assert.Equal(test, collection.ErrDuplicatedElement, err, "Collecting duplicated elements should return an error")
+}
+
+func TestDictionaryStreams(test *testing.T) {
+	source, _ := dictionary.NewDictionary([]dictionary.KeyValueElement{{Key: "1Key", Value: 1}, {Key: "2Key", Value: 2}})
+
+	result, err := FromDictionary(source).
+		Filter(func(element collection.Element) bool { return element.(dictionary.KeyValueElement).Value.(int) > 1 }).
+		ToDictionary()
+
+	assert.Nil(test, err, "Unexpected error collecting a stream into a dictionary")
+	assert.Equal(test, dictionary.KeyValueMap{"2Key": 2}, *result.Elements(), "Wrong elements collecting a stream into a dictionary")
+
+	_, err = FromCollection(collection.NewCollection([]int{1})).ToDictionary()
+
+	assert.Equal(test, dictionary.ErrInvalidKeyValueElementType, err, "Collecting non key-value elements into a dictionary should return an error")
+}